@@ -0,0 +1,37 @@
+package astronomy
+
+import "time"
+
+// JulianDateJ2000 is the Julian Date of the J2000 epoch (2000-01-01 12:00 TT),
+// which astronomical formulas count from
+const JulianDateJ2000 = 2451545.0
+
+const (
+	julianDateUnixEpoch = 2440587.5 // Julian Date of 1970-01-01 00:00 UTC
+	secondsPerDay       = 86400
+)
+
+// JulianDate returns the Julian Date (days since noon UTC on 1 January 4713
+// BC in the proleptic Julian calendar) of t, ignoring leap seconds like Unix
+// time does
+// See: https://en.wikipedia.org/wiki/Julian_day
+func JulianDate(t time.Time) float64 {
+	// Seconds and nanoseconds separately, as UnixNano overflows before 1678
+	return julianDateUnixEpoch + (float64(t.Unix())+float64(t.Nanosecond())/1e9)/secondsPerDay
+}
+
+// timeFromJulianDate returns the UTC time of a Julian Date
+func timeFromJulianDate(jd float64) time.Time {
+	seconds := (jd - julianDateUnixEpoch) * secondsPerDay
+	return time.Unix(0, int64(seconds*1e9)).UTC()
+}
+
+// deltaT returns an estimate of ΔT, the difference between Terrestrial Time
+// (TT), which the formulas of Meeus use, and UT in seconds at the Julian Date.
+// The polynomial of Espenak and Meeus for 2005-2050 is within about 20
+// seconds of the observed ΔT since 1970.
+// See: https://eclipse.gsfc.nasa.gov/SEcat5/deltatpoly.html
+func deltaT(jd float64) float64 {
+	y := (jd - JulianDateJ2000) / 365.25 // Years since 2000
+	return 62.92 + 0.32217*y + 0.005589*y*y
+}
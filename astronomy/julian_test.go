@@ -0,0 +1,36 @@
+package astronomy
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestJulianDate(t *testing.T) {
+	tests := []struct {
+		time     time.Time
+		expected float64
+	}{
+		{time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC), 2451545.0},
+		{time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), 2440587.5},
+		{time.Date(1987, 4, 10, 19, 21, 0, 0, time.UTC), 2446896.30625},
+		{time.Date(2023, 10, 1, 15, 16, 17, 0, time.UTC), 2460219.136307870},
+		// Before 1678, outside the range of UnixNano (Meeus, Astronomical Algorithms, table 7.a)
+		{time.Date(1600, 1, 1, 0, 0, 0, 0, time.UTC), 2305447.5},
+		// Time zones do not change the Julian Date
+		{time.Date(2000, 1, 1, 13, 0, 0, 0, time.FixedZone("CET", 3600)), 2451545.0},
+	}
+
+	for _, tt := range tests {
+		if got := JulianDate(tt.time); math.Abs(got-tt.expected) > 1e-8 {
+			t.Errorf("JulianDate(%v): expected %.8f, got %.8f", tt.time, tt.expected, got)
+		}
+	}
+}
+
+func TestTimeFromJulianDate(t *testing.T) {
+	expected := time.Date(2023, 10, 1, 15, 16, 17, 0, time.UTC)
+	if got := timeFromJulianDate(JulianDate(expected)); got.Sub(expected).Abs() > time.Millisecond {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
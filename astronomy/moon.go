@@ -0,0 +1,144 @@
+package astronomy
+
+import (
+	"math"
+	"time"
+)
+
+var moonPhaseNames = [...]string{
+	"New Moon",
+	"Waxing Crescent",
+	"First Quarter",
+	"Waxing Gibbous",
+	"Full Moon",
+	"Waning Gibbous",
+	"Last Quarter",
+	"Waning Crescent",
+}
+
+type MoonPhase struct {
+	Elongation   float64 // Angle between moon and sun as seen from Earth, 0-360 degrees from new moon
+	Illumination float64 // Illuminated fraction of the disk, 0-1
+	Name         string
+}
+
+// MoonPhaseAt returns the phase of the moon at t, computed from the mean
+// elongation and the main periodic terms of the moon's orbit (Meeus,
+// Astronomical Algorithms, chapter 48). The illumination is accurate to about
+// one percent.
+func MoonPhaseAt(t time.Time) MoonPhase {
+	// Julian centuries since J2000
+	T := (JulianDate(t) - JulianDateJ2000) / 36525
+
+	D := radians(NormalizeDegrees(297.8501921 + 445267.1114034*T))  // Mean elongation of the moon
+	M := radians(NormalizeDegrees(357.5291092 + 35999.0502909*T))   // Mean anomaly of the sun
+	Mp := radians(NormalizeDegrees(134.9633964 + 477198.8675055*T)) // Mean anomaly of the moon
+
+	phaseAngle := 180 - degrees(D) -
+		6.289*math.Sin(Mp) +
+		2.100*math.Sin(M) -
+		1.274*math.Sin(2*D-Mp) -
+		0.658*math.Sin(2*D) -
+		0.214*math.Sin(2*Mp) -
+		0.110*math.Sin(D)
+
+	elongation := NormalizeDegrees(180 - phaseAngle)
+	return MoonPhase{
+		Elongation:   elongation,
+		Illumination: (1 + math.Cos(radians(phaseAngle))) / 2,
+		Name:         moonPhaseNames[int(NormalizeDegrees(elongation+22.5)/45)%len(moonPhaseNames)],
+	}
+}
+
+// NormalizeDegrees returns the angle in degrees reduced to [0, 360)
+func NormalizeDegrees(degrees float64) float64 {
+	degrees = math.Mod(degrees, 360)
+	if degrees < 0 {
+		degrees += 360
+	}
+	return degrees
+}
+
+// Mean synodic month and the time of the first new moon of 2000 (lunation 0),
+// in Julian Days
+const (
+	synodicMonth      = 29.530588861
+	julianDateNewMoon = 2451550.09766
+)
+
+// NewMoon returns the time of the new moon of the lunation, counted from the
+// first new moon of 2000 (2000-01-06), computed with the periodic terms of
+// Meeus (Astronomical Algorithms, chapter 49), accurate to about half a
+// minute.
+func NewMoon(lunation int) time.Time {
+	k := float64(lunation)
+	T := k / 1236.85 // Julian centuries since J2000
+	jd := julianDateNewMoon + synodicMonth*k + 0.00015437*T*T - 0.000000150*T*T*T + 0.00000000073*T*T*T*T
+
+	// Eccentricity of the Earth's orbit
+	E := 1 - 0.002516*T - 0.0000074*T*T
+	// Mean anomaly of the sun
+	M := radians(2.5534 + 29.10535670*k - 0.0000014*T*T - 0.00000011*T*T*T)
+	// Mean anomaly of the moon
+	Mp := radians(201.5643 + 385.81693528*k + 0.0107582*T*T + 0.00001238*T*T*T - 0.000000058*T*T*T*T)
+	// Argument of latitude of the moon
+	F := radians(160.7108 + 390.67050284*k - 0.0016118*T*T - 0.00000227*T*T*T + 0.000000011*T*T*T*T)
+	// Longitude of the ascending node
+	omega := radians(124.7746 - 1.56375588*k + 0.0020672*T*T + 0.00000215*T*T*T)
+
+	jd += -0.40720*math.Sin(Mp) +
+		0.17241*E*math.Sin(M) +
+		0.01608*math.Sin(2*Mp) +
+		0.01039*math.Sin(2*F) +
+		0.00739*E*math.Sin(Mp-M) -
+		0.00514*E*math.Sin(Mp+M) +
+		0.00208*E*E*math.Sin(2*M) -
+		0.00111*math.Sin(Mp-2*F) -
+		0.00057*math.Sin(Mp+2*F) +
+		0.00056*E*math.Sin(2*Mp+M) -
+		0.00042*math.Sin(3*Mp) +
+		0.00042*E*math.Sin(M+2*F) +
+		0.00038*E*math.Sin(M-2*F) -
+		0.00024*E*math.Sin(2*Mp-M) -
+		0.00017*math.Sin(omega) -
+		0.00007*math.Sin(Mp+2*M) +
+		0.00004*math.Sin(2*Mp-2*F) +
+		0.00004*math.Sin(3*M) +
+		0.00003*math.Sin(Mp+M-2*F) +
+		0.00003*math.Sin(2*Mp+2*F) -
+		0.00003*math.Sin(Mp+M+2*F) +
+		0.00003*math.Sin(Mp-M+2*F) -
+		0.00002*math.Sin(Mp-M-2*F) -
+		0.00002*math.Sin(3*Mp+M) +
+		0.00002*math.Sin(4*Mp)
+
+	// Planetary perturbations
+	planetary := [...]struct{ amplitude, a0, a1 float64 }{
+		{0.000325, 299.77, 0.107408}, {0.000165, 251.88, 0.016321}, {0.000164, 251.83, 26.651886},
+		{0.000126, 349.42, 36.412478}, {0.000110, 84.66, 18.206239}, {0.000062, 141.74, 53.303771},
+		{0.000060, 207.14, 2.453732}, {0.000056, 154.84, 7.306860}, {0.000047, 34.52, 27.261239},
+		{0.000042, 207.19, 0.121824}, {0.000040, 291.34, 1.844379}, {0.000037, 161.72, 24.198154},
+		{0.000035, 239.56, 25.513099}, {0.000023, 331.55, 3.592518},
+	}
+	for i, term := range planetary {
+		argument := term.a0 + term.a1*k
+		if i == 0 {
+			argument -= 0.009173 * T * T
+		}
+		jd += term.amplitude * math.Sin(radians(argument))
+	}
+	return timeFromJulianDate(jd - deltaT(jd)/secondsPerDay)
+}
+
+// LunationAt returns the lunation of the last new moon at or before t
+func LunationAt(t time.Time) int {
+	lunation := int(math.Floor((JulianDate(t) - julianDateNewMoon) / synodicMonth))
+	// The true new moon is up to about 14 hours from the mean new moon
+	for NewMoon(lunation).After(t) {
+		lunation--
+	}
+	for !NewMoon(lunation + 1).After(t) {
+		lunation++
+	}
+	return lunation
+}
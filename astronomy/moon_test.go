@@ -0,0 +1,77 @@
+package astronomy
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Reference times of the principal moon phases from the US Naval Observatory
+// See: https://aa.usno.navy.mil/data/MoonPhases
+func TestMoonPhaseAt(t *testing.T) {
+	tests := []struct {
+		time         time.Time
+		illumination float64
+		name         string
+	}{
+		{time.Date(2023, 8, 31, 1, 35, 0, 0, time.UTC), 1, "Full Moon"},
+		{time.Date(2023, 9, 15, 1, 40, 0, 0, time.UTC), 0, "New Moon"},
+		{time.Date(2023, 9, 22, 19, 32, 0, 0, time.UTC), 0.5, "First Quarter"},
+		{time.Date(2023, 10, 6, 13, 48, 0, 0, time.UTC), 0.5, "Last Quarter"},
+		// Between the principal phases
+		{time.Date(2023, 9, 18, 12, 0, 0, 0, time.UTC), 0.1, "Waxing Crescent"},
+		{time.Date(2023, 9, 26, 12, 0, 0, 0, time.UTC), 0.9, "Waxing Gibbous"},
+		{time.Date(2023, 10, 2, 12, 0, 0, 0, time.UTC), 0.85, "Waning Gibbous"},
+		{time.Date(2023, 10, 11, 12, 0, 0, 0, time.UTC), 0.1, "Waning Crescent"},
+	}
+
+	for _, tt := range tests {
+		phase := MoonPhaseAt(tt.time)
+		if phase.Name != tt.name {
+			t.Errorf("MoonPhaseAt(%v): expected %q, got %q", tt.time, tt.name, phase.Name)
+		}
+		if math.Abs(phase.Illumination-tt.illumination) > 0.1 {
+			t.Errorf("MoonPhaseAt(%v): expected illumination %.2f, got %.2f", tt.time, tt.illumination, phase.Illumination)
+		}
+	}
+}
+
+// Reference times from the US Naval Observatory, like TestMoonPhaseAt
+func TestNewMoon(t *testing.T) {
+	tests := []struct {
+		lunation int
+		expected time.Time
+	}{
+		{0, time.Date(2000, 1, 6, 18, 14, 0, 0, time.UTC)},
+		// Meeus, Astronomical Algorithms, example 49.a (03:37:42 TT, ΔT was 48 seconds)
+		{-283, time.Date(1977, 2, 18, 3, 36, 54, 0, time.UTC)},
+		{297, time.Date(2024, 1, 11, 11, 57, 0, 0, time.UTC)},
+		{316, time.Date(2025, 7, 24, 19, 11, 0, 0, time.UTC)},
+		{323, time.Date(2026, 2, 17, 12, 1, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		if got := NewMoon(tt.lunation); got.Sub(tt.expected).Abs() > time.Minute {
+			t.Errorf("NewMoon(%d): expected %v, got %v", tt.lunation, tt.expected, got)
+		}
+	}
+}
+
+func TestLunationAt(t *testing.T) {
+	tests := []struct {
+		time     time.Time
+		expected int
+	}{
+		{time.Date(2000, 1, 6, 18, 0, 0, 0, time.UTC), -1},
+		{time.Date(2000, 1, 6, 18, 30, 0, 0, time.UTC), 0},
+		{time.Date(2026, 2, 17, 11, 0, 0, 0, time.UTC), 322},
+		{time.Date(2026, 2, 17, 13, 0, 0, 0, time.UTC), 323},
+		{time.Date(1977, 3, 1, 0, 0, 0, 0, time.UTC), -283},
+	}
+
+	for _, tt := range tests {
+		if got := LunationAt(tt.time); got != tt.expected {
+			t.Errorf("LunationAt(%v): expected %d, got %d", tt.time, tt.expected, got)
+		}
+	}
+}
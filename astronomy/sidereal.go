@@ -0,0 +1,33 @@
+package astronomy
+
+import (
+	"math"
+	"time"
+)
+
+// GreenwichMeanSiderealTime returns the Greenwich Mean Sidereal Time (GMST)
+// in degrees, using the IAU 1982 expression (Meeus, Astronomical Algorithms,
+// formula 12.4). UTC is used for UT1, which differs by less than a second.
+// See: https://en.wikipedia.org/wiki/Sidereal_time
+func GreenwichMeanSiderealTime(t time.Time) float64 {
+	d := JulianDate(t) - JulianDateJ2000
+	T := d / 36525 // Julian centuries since J2000
+	return NormalizeDegrees(280.46061837 + 360.98564736629*d + 0.000387933*T*T - T*T*T/38710000)
+}
+
+// GreenwichApparentSiderealTime returns the Greenwich Apparent Sidereal Time
+// (GAST) in degrees: GMST corrected by the equation of the equinoxes, with the
+// main terms of the nutation (Meeus, chapter 22)
+func GreenwichApparentSiderealTime(t time.Time) float64 {
+	T := (JulianDate(t) - JulianDateJ2000) / 36525
+	omega := radians(125.04452 - 1934.136261*T) // Longitude of the moon's ascending node
+	L := radians(280.4665 + 36000.7698*T)       // Mean longitude of the sun
+	L1 := radians(218.3165 + 481267.8813*T)     // Mean longitude of the moon
+
+	// Nutation in longitude and obliquity, in arcseconds
+	deltaPsi := -17.20*math.Sin(omega) - 1.32*math.Sin(2*L) - 0.23*math.Sin(2*L1) + 0.21*math.Sin(2*omega)
+	deltaEpsilon := 9.20*math.Cos(omega) + 0.57*math.Cos(2*L) + 0.10*math.Cos(2*L1) - 0.09*math.Cos(2*omega)
+	epsilon := 23.4392911 - 0.0130042*T + deltaEpsilon/3600 // True obliquity of the ecliptic, in degrees
+
+	return NormalizeDegrees(GreenwichMeanSiderealTime(t) + deltaPsi/3600*math.Cos(radians(epsilon)))
+}
@@ -0,0 +1,51 @@
+package astronomy
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSiderealTime(t *testing.T) {
+	// Meeus, Astronomical Algorithms, examples 12.a and 12.b
+	tests := []struct {
+		time     time.Time
+		apparent bool
+		expected float64 // In degrees
+	}{
+		{time.Date(1987, 4, 10, 0, 0, 0, 0, time.UTC), false, 197.693195},
+		{time.Date(1987, 4, 10, 19, 21, 0, 0, time.UTC), false, 128.7378734},
+		// 13h10m46.1351s
+		{time.Date(1987, 4, 10, 0, 0, 0, 0, time.UTC), true, 197.6922296},
+	}
+
+	for _, tt := range tests {
+		got := GreenwichMeanSiderealTime(tt.time)
+		if tt.apparent {
+			got = GreenwichApparentSiderealTime(tt.time)
+		}
+		// 0.0001° is 0.024 seconds of sidereal time
+		if math.Abs(got-tt.expected) > 0.0001 {
+			t.Errorf("sidereal time (apparent %v) at %v: expected %.7f, got %.7f", tt.apparent, tt.time, tt.expected, got)
+		}
+	}
+}
+
+func TestNormalizeDegrees(t *testing.T) {
+	tests := []struct {
+		degrees  float64
+		expected float64
+	}{
+		{0, 0},
+		{359.5, 359.5},
+		{360, 0},
+		{725, 5},
+		{-90, 270},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeDegrees(tt.degrees); got != tt.expected {
+			t.Errorf("NormalizeDegrees(%g): expected %g, got %g", tt.degrees, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package astronomy
+
+import (
+	"math"
+	"time"
+)
+
+// Altitudes of the center of the sun for the events of a day, in degrees.
+// Sunrise and sunset account for atmospheric refraction and the radius of the
+// sun.
+const (
+	sunriseAltitude              = -0.833
+	civilTwilightAltitude        = -6
+	nauticalTwilightAltitude     = -12
+	astronomicalTwilightAltitude = -18
+	earthObliquity               = 23.4397
+)
+
+// tropicalYear is the mean time between two March equinoxes in days
+const tropicalYear = 365.2422
+
+// SunTimes holds the times of the solar events of a day. Times are zero if
+// the event does not happen on that day (e.g. no sunset during the midnight
+// sun).
+type SunTimes struct {
+	AstronomicalDawn time.Time
+	NauticalDawn     time.Time
+	CivilDawn        time.Time
+	Sunrise          time.Time
+	SolarNoon        time.Time
+	Sunset           time.Time
+	CivilDusk        time.Time
+	NauticalDusk     time.Time
+	AstronomicalDusk time.Time
+}
+
+// SunTimesOn returns the solar events on the calendar date of t (in the
+// location of t) at the given latitude and longitude (in degrees, north and
+// east positive), computed with the sunrise equation. The times are accurate
+// to about a minute, except close to the polar circles.
+// See: https://en.wikipedia.org/wiki/Sunrise_equation
+func SunTimesOn(t time.Time, latitude float64, longitude float64) SunTimes {
+	year, month, day := t.Date()
+	// Days since J2000 of noon UTC on the calendar date, the correction for
+	// longitude moves it to the local mean solar noon
+	n := math.Round(JulianDate(time.Date(year, month, day, 12, 0, 0, 0, time.UTC)) - JulianDateJ2000)
+	meanSolarTime := n - longitude/360
+
+	meanAnomaly := math.Mod(357.5291+0.98560028*meanSolarTime, 360)
+	m := radians(meanAnomaly)
+	center := 1.9148*math.Sin(m) + 0.0200*math.Sin(2*m) + 0.0003*math.Sin(3*m)
+	eclipticLongitude := radians(math.Mod(meanAnomaly+center+180+102.9372, 360))
+	transit := JulianDateJ2000 + meanSolarTime + 0.0053*math.Sin(m) - 0.0069*math.Sin(2*eclipticLongitude)
+	declination := math.Asin(math.Sin(eclipticLongitude) * math.Sin(radians(earthObliquity)))
+
+	location := t.Location()
+	events := func(altitude float64) (time.Time, time.Time) {
+		phi := radians(latitude)
+		cosHourAngle := (math.Sin(radians(altitude)) - math.Sin(phi)*math.Sin(declination)) / (math.Cos(phi) * math.Cos(declination))
+		if cosHourAngle < -1 || cosHourAngle > 1 {
+			// The sun stays above or below the altitude all day
+			return time.Time{}, time.Time{}
+		}
+		hourAngle := degrees(math.Acos(cosHourAngle))
+		return timeFromJulianDate(transit - hourAngle/360).In(location), timeFromJulianDate(transit + hourAngle/360).In(location)
+	}
+
+	times := SunTimes{SolarNoon: timeFromJulianDate(transit).In(location)}
+	times.Sunrise, times.Sunset = events(sunriseAltitude)
+	times.CivilDawn, times.CivilDusk = events(civilTwilightAltitude)
+	times.NauticalDawn, times.NauticalDusk = events(nauticalTwilightAltitude)
+	times.AstronomicalDawn, times.AstronomicalDusk = events(astronomicalTwilightAltitude)
+	return times
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+func degrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
+
+// SolarLongitude returns the apparent ecliptic longitude of the sun at t in
+// degrees (0 at the March equinox, 270 at the December solstice), accurate to
+// about 0.01° (Meeus, Astronomical Algorithms, chapter 25)
+func SolarLongitude(t time.Time) float64 {
+	jd := JulianDate(t)
+	T := (jd + deltaT(jd)/secondsPerDay - JulianDateJ2000) / 36525 // Julian centuries since J2000 (TT)
+	meanLongitude := 280.46646 + 36000.76983*T + 0.0003032*T*T
+	m := radians(357.52911 + 35999.05029*T - 0.0001537*T*T) // Mean anomaly
+	center := (1.914602-0.004817*T-0.000014*T*T)*math.Sin(m) + (0.019993-0.000101*T)*math.Sin(2*m) + 0.000289*math.Sin(3*m)
+	omega := radians(125.04 - 1934.136*T) // Longitude of the moon's ascending node
+	// Corrected for nutation and aberration
+	return NormalizeDegrees(meanLongitude + center - 0.00569 - 0.00478*math.Sin(omega))
+}
+
+// SolarLongitudeTime returns the time closest to near when the apparent
+// ecliptic longitude of the sun is the given longitude in degrees (e.g. 180
+// for the September equinox)
+func SolarLongitudeTime(longitude float64, near time.Time) time.Time {
+	t := near
+	for range 5 {
+		// The sun moves about 1° per day
+		correction := math.Remainder(longitude-SolarLongitude(t), 360) / 360 * tropicalYear
+		t = t.Add(time.Duration(correction * float64(24*time.Hour)))
+	}
+	return t
+}
@@ -0,0 +1,130 @@
+package astronomy
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func mustLoadLocation(name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return location
+}
+
+// assertNear fails if got is more than two minutes from the expected clock
+// time (hh:mm) on the same day, or "" if the event does not happen
+func assertNear(t *testing.T, name string, got time.Time, expected string) {
+	t.Helper()
+	if expected == "" {
+		if !got.IsZero() {
+			t.Errorf("%s: expected no event, got %v", name, got)
+		}
+		return
+	}
+	clock, err := time.ParseInLocation("15:04", expected, got.Location())
+	if err != nil {
+		t.Fatalf("invalid expected time %q", expected)
+	}
+	year, month, day := got.Date()
+	want := time.Date(year, month, day, clock.Hour(), clock.Minute(), 0, 0, got.Location())
+	if got.Sub(want).Abs() > 2*time.Minute {
+		t.Errorf("%s: expected %s, got %s", name, expected, got.Format("15:04:05"))
+	}
+}
+
+// Reference times from published sunrise and sunset tables, rounded to the
+// minute
+func TestSunTimesOn(t *testing.T) {
+	tests := []struct {
+		name                  string
+		date                  time.Time
+		latitude, longitude   float64
+		sunrise, noon, sunset string
+	}{
+		{"London summer solstice", time.Date(2023, 6, 21, 0, 0, 0, 0, mustLoadLocation("Europe/London")), 51.5074, -0.1278, "04:43", "13:02", "21:21"},
+		{"Berlin winter solstice", time.Date(2023, 12, 21, 23, 0, 0, 0, mustLoadLocation("Europe/Berlin")), 52.52, 13.405, "08:15", "12:04", "15:54"},
+	}
+
+	for _, tt := range tests {
+		times := SunTimesOn(tt.date, tt.latitude, tt.longitude)
+		assertNear(t, tt.name+" sunrise", times.Sunrise, tt.sunrise)
+		assertNear(t, tt.name+" solar noon", times.SolarNoon, tt.noon)
+		assertNear(t, tt.name+" sunset", times.Sunset, tt.sunset)
+	}
+}
+
+func TestSunTimesOn_Polar(t *testing.T) {
+	tromso := mustLoadLocation("Europe/Oslo")
+
+	// Midnight sun: the sun does not set, and it does not get dark at all
+	times := SunTimesOn(time.Date(2023, 6, 21, 0, 0, 0, 0, tromso), 69.6496, 18.956)
+	assertNear(t, "midnight sun sunrise", times.Sunrise, "")
+	assertNear(t, "midnight sun sunset", times.Sunset, "")
+	assertNear(t, "midnight sun civil dusk", times.CivilDusk, "")
+	if times.SolarNoon.IsZero() {
+		t.Errorf("expected solar noon during the midnight sun")
+	}
+
+	// Polar night: the sun does not rise, but there is civil twilight
+	times = SunTimesOn(time.Date(2023, 12, 21, 0, 0, 0, 0, tromso), 69.6496, 18.956)
+	assertNear(t, "polar night sunrise", times.Sunrise, "")
+	assertNear(t, "polar night sunset", times.Sunset, "")
+	if times.CivilDawn.IsZero() || times.CivilDusk.IsZero() || !times.CivilDawn.Before(times.SolarNoon) || !times.SolarNoon.Before(times.CivilDusk) {
+		t.Errorf("expected civil twilight around solar noon during the polar night, got %v - %v", times.CivilDawn, times.CivilDusk)
+	}
+}
+
+func TestSunTimesOn_Order(t *testing.T) {
+	times := SunTimesOn(time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC), 48.8566, 2.3522)
+	events := []time.Time{
+		times.AstronomicalDawn, times.NauticalDawn, times.CivilDawn, times.Sunrise, times.SolarNoon,
+		times.Sunset, times.CivilDusk, times.NauticalDusk, times.AstronomicalDusk,
+	}
+	for i := 1; i < len(events); i++ {
+		if !events[i-1].Before(events[i]) {
+			t.Errorf("expected event %d (%v) before event %d (%v)", i-1, events[i-1], i, events[i])
+		}
+	}
+}
+
+func TestSolarLongitude(t *testing.T) {
+	tests := []struct {
+		time     time.Time
+		expected float64
+	}{
+		// Meeus, Astronomical Algorithms, example 25.a
+		{time.Date(1992, 10, 13, 0, 0, 0, 0, time.UTC), 199.90895},
+		// Equinoxes and solstices
+		{time.Date(2026, 3, 20, 14, 46, 0, 0, time.UTC), 0},
+		{time.Date(2026, 6, 21, 8, 24, 0, 0, time.UTC), 90},
+		{time.Date(2026, 12, 21, 20, 50, 0, 0, time.UTC), 270},
+	}
+
+	for _, tt := range tests {
+		got := SolarLongitude(tt.time)
+		if diff := math.Abs(math.Remainder(got-tt.expected, 360)); diff > 0.01 {
+			t.Errorf("SolarLongitude(%v): expected %.5f, got %.5f", tt.time, tt.expected, got)
+		}
+	}
+}
+
+func TestSolarLongitudeTime(t *testing.T) {
+	tests := []struct {
+		longitude float64
+		near      time.Time
+		expected  time.Time
+	}{
+		{0, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 20, 14, 46, 0, 0, time.UTC)},
+		{180, time.Date(2026, 9, 22, 0, 0, 0, 0, time.UTC), time.Date(2026, 9, 23, 0, 5, 0, 0, time.UTC)},
+		{270, time.Date(2026, 12, 21, 0, 0, 0, 0, time.UTC), time.Date(2026, 12, 21, 20, 50, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		if got := SolarLongitudeTime(tt.longitude, tt.near); got.Sub(tt.expected).Abs() > 15*time.Minute {
+			t.Errorf("SolarLongitudeTime(%v, %v): expected %v, got %v", tt.longitude, tt.near, tt.expected, got)
+		}
+	}
+}
@@ -9,31 +9,24 @@ import (
 	"github.com/ebitengine/oto/v3"
 )
 
-var (
-	shortBeep     []byte
-	longBeep      []byte
-	currentSecond int
-)
-
-func init() {
-	shortBeep = makeSineWaveTable(shortMs)
-	longBeep = makeSineWaveTable(longMs)
-}
+var currentSecond int
 
-func BeepTick(ctx *oto.Context, now time.Time) {
-	if !shouldBeep(now) || currentSecond == now.Second() {
+// BeepTick plays the pulses of the time signal (one of AllowedTimeSignals) for
+// the second starting at now
+func BeepTick(ctx *oto.Context, now time.Time, signal string) {
+	// Ticks are a few milliseconds after the second
+	now = now.Round(time.Second)
+	pulses := timeSignals[signal](now)
+	if len(pulses) == 0 || currentSecond == now.Second() {
 		return
 	}
 
 	currentSecond = now.Second()
 
-	go func(currentSecond int) {
-		if currentSecond == 0 {
-			playBeep(ctx, longBeep, longMs)
-		} else {
-			playBeep(ctx, shortBeep, shortMs)
-		}
-	}(currentSecond)
+	go func() {
+		data := renderPulses(pulses)
+		playBeep(ctx, data, len(data)/2*1000/sampleRate)
+	}()
 }
 
 func shouldBeep(now time.Time) bool {
@@ -50,11 +43,15 @@ func playBeep(ctx *oto.Context, data []byte, durationMs int) {
 }
 
 func makeSineWaveTable(durationMs int) []byte {
+	return makeSineWaveTableAt(freq, durationMs)
+}
+
+func makeSineWaveTableAt(frequency float64, durationMs int) []byte {
 	numSamples := sampleRate * durationMs / 1000
 	buf := make([]byte, numSamples*2) // 2 bytes per sample
 	for i := range numSamples {
 		t := float64(i) / float64(sampleRate)
-		v := int16(math.Sin(2*math.Pi*freq*t) * amplitude * maxInt16)
+		v := int16(math.Sin(2*math.Pi*frequency*t) * amplitude * maxInt16)
 		buf[2*i] = byte(v)
 		buf[2*i+1] = byte(v >> 8)
 	}
@@ -0,0 +1,255 @@
+package audio
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ebitengine/oto/v3"
+)
+
+// AllowedChimes lists the chime styles:
+//   - none: no chimes
+//   - westminster: the Westminster Quarters melodies every quarter hour,
+//     followed by the hour strikes on the full hour
+//   - strike: hour strikes counting the hour
+//   - quarter: hour strikes and a single ping on the quarter hours
+//   - cuckoo: cuckoo calls counting the hour, and a single call on the half hour
+var AllowedChimes = [...]string{"none", "westminster", "strike", "quarter", "cuckoo"}
+
+// Chimes are the chime style and the quiet hours without chimes (nil for
+// none)
+type Chimes struct {
+	Style      string
+	QuietHours *QuietHours
+}
+
+var (
+	// Church bells, with the hum, prime, tierce, quint and nominal partials
+	// of a tuned bell and a long decay
+	quarterBell = voice{
+		partials: []partial{{0.5, 0.4}, {1, 1}, {1.2, 0.5}, {1.5, 0.3}, {2, 0.6}, {2.5, 0.15}, {3, 0.1}},
+		attack:   5 * time.Millisecond,
+		decay:    1200 * time.Millisecond,
+		release:  100 * time.Millisecond,
+		length:   3 * time.Second,
+	}
+	hourBell = voice{
+		partials: quarterBell.partials,
+		attack:   5 * time.Millisecond,
+		decay:    2 * time.Second,
+		release:  200 * time.Millisecond,
+		length:   5 * time.Second,
+	}
+	// A small bell, with the inharmonic partials of a struck metal bar
+	pingBell = voice{
+		partials: []partial{{1, 1}, {2.76, 0.4}, {5.40, 0.15}},
+		attack:   2 * time.Millisecond,
+		decay:    400 * time.Millisecond,
+		release:  50 * time.Millisecond,
+		length:   1500 * time.Millisecond,
+	}
+	// A wooden pipe of a cuckoo clock, which sustains while the bellows blow
+	cuckooPipe = voice{
+		partials: []partial{{1, 1}, {2, 0.25}, {3, 0.1}},
+		attack:   25 * time.Millisecond,
+		release:  60 * time.Millisecond,
+		length:   250 * time.Millisecond,
+	}
+)
+
+// Frequencies of the bells and pipes, in Hz. The Westminster Quarters are
+// rung in E major, and Big Ben sounds an E.
+const (
+	noteGSharp4 = 415.30
+	noteFSharp4 = 369.99
+	noteE4      = 329.63
+	noteB3      = 246.94
+	noteE3      = 164.81
+	notePing    = 880.0
+	noteCuckoo  = 698.46 // F5, falling a minor third to D5
+	noteCoo     = 587.33
+)
+
+// westminsterChanges are the five changes (sequences of four notes) of the
+// Westminster Quarters
+// See: https://en.wikipedia.org/wiki/Westminster_Quarters
+var westminsterChanges = [5][4]float64{
+	{noteGSharp4, noteFSharp4, noteE4, noteB3},
+	{noteE4, noteGSharp4, noteFSharp4, noteB3},
+	{noteE4, noteFSharp4, noteGSharp4, noteE4},
+	{noteGSharp4, noteE4, noteFSharp4, noteB3},
+	{noteB3, noteFSharp4, noteGSharp4, noteE4},
+}
+
+// westminsterQuarters are the changes rung on the full hour, at quarter past,
+// half past and quarter to
+var westminsterQuarters = [4][]int{{1, 2, 3, 4}, {0}, {1, 2}, {3, 4, 0}}
+
+const (
+	westminsterBeat = 700 * time.Millisecond // Between the notes of a change
+	// westminsterHourLead is the time from the start of the full hour melody to
+	// the first hour strike, which marks the hour
+	westminsterHourLead = 16 * time.Second
+	strikeInterval      = 2500 * time.Millisecond
+	cuckooInterval      = time.Second
+	cuckooGap           = 300 * time.Millisecond // Between cu and ckoo
+)
+
+// chime is a timeline of notes that announces a quarter hour mark
+type chime struct {
+	lead  time.Duration // Time from the start of the timeline to the mark
+	notes []note
+}
+
+// chimeFor returns the chime of the style for the quarter hour mark, if it
+// chimes then
+func chimeFor(style string, mark time.Time) (chime, bool) {
+	quarter := mark.Minute() / 15
+	switch style {
+	case "westminster":
+		return westminsterChime(quarter, mark.Hour()), true
+	case "strike":
+		if quarter == 0 {
+			return chime{notes: hourStrikes(0, mark.Hour())}, true
+		}
+	case "quarter":
+		if quarter == 0 {
+			return chime{notes: hourStrikes(0, mark.Hour())}, true
+		}
+		return chime{notes: []note{{0, notePing, &pingBell}}}, true
+	case "cuckoo":
+		switch quarter {
+		case 0:
+			return chime{notes: cuckooCalls(strikeCount(mark.Hour()))}, true
+		case 2:
+			return chime{notes: cuckooCalls(1)}, true
+		}
+	}
+	return chime{}, false
+}
+
+// westminsterChime returns the changes of the quarter (0 for the full hour).
+// The quarter melodies start on the mark; on the full hour, the melody comes
+// first, and the first hour strike is on the hour, like Big Ben.
+func westminsterChime(quarter int, hour int) chime {
+	notes := []note{}
+	offset := time.Duration(0)
+	for _, change := range westminsterQuarters[quarter] {
+		for _, frequency := range westminsterChanges[change] {
+			notes = append(notes, note{offset, frequency, &quarterBell})
+			offset += westminsterBeat
+		}
+		offset += westminsterBeat // Rest after each change
+	}
+	if quarter != 0 {
+		return chime{notes: notes}
+	}
+	return chime{lead: westminsterHourLead, notes: append(notes, hourStrikes(westminsterHourLead, hour)...)}
+}
+
+// strikeCount returns the number of strikes for the hour on a 12-hour clock
+func strikeCount(hour int) int {
+	if hour%12 == 0 {
+		return 12
+	}
+	return hour % 12
+}
+
+// hourStrikes returns the strikes of the hour bell counting the hour,
+// starting at the offset
+func hourStrikes(offset time.Duration, hour int) []note {
+	notes := []note{}
+	for i := range strikeCount(hour) {
+		notes = append(notes, note{offset + time.Duration(i)*strikeInterval, noteE3, &hourBell})
+	}
+	return notes
+}
+
+// cuckooCalls returns the calls of a cuckoo clock, two falling notes each
+func cuckooCalls(count int) []note {
+	notes := []note{}
+	for i := range count {
+		offset := time.Duration(i) * cuckooInterval
+		notes = append(notes, note{offset, noteCuckoo, &cuckooPipe}, note{offset + cuckooGap, noteCoo, &cuckooPipe})
+	}
+	return notes
+}
+
+// QuietHours is a daily period without chimes, which may span midnight
+type QuietHours struct {
+	Start time.Duration // Since midnight
+	End   time.Duration
+}
+
+// ParseQuietHours parses quiet hours such as "22:00-07:00". An empty string
+// is no quiet hours (nil).
+func ParseQuietHours(s string) (*QuietHours, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var startHour, startMinute, endHour, endMinute int
+	var rest string
+	// Anything after the end time is scanned into rest, which must stay empty
+	if n, _ := fmt.Sscanf(s, "%d:%d-%d:%d%s", &startHour, &startMinute, &endHour, &endMinute, &rest); n != 4 {
+		return nil, fmt.Errorf("invalid quiet hours '%s', expected HH:MM-HH:MM (e.g. 22:00-07:00)", s)
+	}
+	for _, clock := range [][2]int{{startHour, startMinute}, {endHour, endMinute}} {
+		if clock[0] < 0 || clock[0] > 23 || clock[1] < 0 || clock[1] > 59 {
+			return nil, fmt.Errorf("invalid quiet hours '%s', times must be between 00:00 and 23:59", s)
+		}
+	}
+	quietHours := &QuietHours{
+		Start: time.Duration(startHour)*time.Hour + time.Duration(startMinute)*time.Minute,
+		End:   time.Duration(endHour)*time.Hour + time.Duration(endMinute)*time.Minute,
+	}
+	if quietHours.Start == quietHours.End {
+		return nil, fmt.Errorf("invalid quiet hours '%s', start and end must differ", s)
+	}
+	return quietHours, nil
+}
+
+// Contains reports whether the wall clock time of t is in the quiet hours.
+// The start is quiet, the end is not.
+func (q *QuietHours) Contains(t time.Time) bool {
+	if q == nil {
+		return false
+	}
+	hour, minute, second := t.Clock()
+	clock := time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute + time.Duration(second)*time.Second
+	if q.Start < q.End {
+		return clock >= q.Start && clock < q.End
+	}
+	return clock >= q.Start || clock < q.End
+}
+
+// nextQuarterHour returns the first quarter hour of the wall clock at or after
+// t
+func nextQuarterHour(t time.Time) time.Time {
+	year, month, day := t.Date()
+	hour, minute, _ := t.Clock()
+	mark := time.Date(year, month, day, hour, minute-minute%15, 0, 0, t.Location())
+	if mark.Before(t) {
+		mark = mark.Add(15 * time.Minute)
+	}
+	return mark
+}
+
+var lastChime time.Time
+
+// ChimeTick plays the chime whose timeline starts at now, which is called
+// every second like BeepTick. Chimes that announce a mark inside the quiet
+// hours are not played.
+func ChimeTick(ctx *oto.Context, now time.Time, chimes Chimes) {
+	// Ticks are a few milliseconds after the second
+	now = now.Round(time.Second)
+	mark := nextQuarterHour(now)
+	c, ok := chimeFor(chimes.Style, mark)
+	if !ok || mark.Sub(now) != c.lead || chimes.QuietHours.Contains(mark) || mark.Equal(lastChime) {
+		return
+	}
+	lastChime = mark
+
+	go func() {
+		playBeep(ctx, renderNotes(c.notes), int(timelineLength(c.notes)/time.Millisecond))
+	}()
+}
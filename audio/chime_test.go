@@ -0,0 +1,183 @@
+package audio
+
+import (
+	"testing"
+	"time"
+)
+
+func countNotes(notes []note, v *voice) int {
+	count := 0
+	for _, n := range notes {
+		if n.voice == v {
+			count++
+		}
+	}
+	return count
+}
+
+func TestChimeFor(t *testing.T) {
+	tests := []struct {
+		style         string
+		hour, minute  int
+		ok            bool
+		lead          time.Duration
+		quarterBells  int
+		hourStrikes   int
+		pings, cuckoo int
+	}{
+		{"none", 12, 0, false, 0, 0, 0, 0, 0},
+		// Westminster Quarters: one change at quarter past, two at half past,
+		// three at quarter to and four before the hour strikes
+		{"westminster", 9, 15, true, 0, 4, 0, 0, 0},
+		{"westminster", 9, 30, true, 0, 8, 0, 0, 0},
+		{"westminster", 9, 45, true, 0, 12, 0, 0, 0},
+		{"westminster", 10, 0, true, 16 * time.Second, 16, 10, 0, 0},
+		{"westminster", 0, 0, true, 16 * time.Second, 16, 12, 0, 0},
+		{"strike", 15, 0, true, 0, 0, 3, 0, 0},
+		{"strike", 12, 0, true, 0, 0, 12, 0, 0},
+		{"strike", 15, 30, false, 0, 0, 0, 0, 0},
+		{"quarter", 15, 0, true, 0, 0, 3, 0, 0},
+		{"quarter", 15, 45, true, 0, 0, 0, 1, 0},
+		// Two notes per call
+		{"cuckoo", 7, 0, true, 0, 0, 0, 0, 14},
+		{"cuckoo", 7, 30, true, 0, 0, 0, 0, 2},
+		{"cuckoo", 7, 15, false, 0, 0, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		mark := time.Date(2026, 10, 19, tt.hour, tt.minute, 0, 0, time.UTC)
+		c, ok := chimeFor(tt.style, mark)
+		if ok != tt.ok {
+			t.Errorf("chimeFor(%q, %02d:%02d): expected %v, got %v", tt.style, tt.hour, tt.minute, tt.ok, ok)
+			continue
+		}
+		if c.lead != tt.lead {
+			t.Errorf("chimeFor(%q, %02d:%02d): expected lead %v, got %v", tt.style, tt.hour, tt.minute, tt.lead, c.lead)
+		}
+		got := [4]int{countNotes(c.notes, &quarterBell), countNotes(c.notes, &hourBell), countNotes(c.notes, &pingBell), countNotes(c.notes, &cuckooPipe)}
+		expected := [4]int{tt.quarterBells, tt.hourStrikes, tt.pings, tt.cuckoo}
+		if got != expected {
+			t.Errorf("chimeFor(%q, %02d:%02d): expected quarter bells, hour strikes, pings and cuckoo notes %v, got %v", tt.style, tt.hour, tt.minute, expected, got)
+		}
+	}
+}
+
+func TestWestminsterChime_Timeline(t *testing.T) {
+	c := westminsterChime(0, 3)
+
+	// The first strike of the hour bell marks the hour, after the melody
+	firstStrike := -1
+	for i, n := range c.notes {
+		if n.voice == &hourBell {
+			firstStrike = i
+			break
+		}
+	}
+	if firstStrike < 0 || c.notes[firstStrike].offset != c.lead {
+		t.Fatalf("expected the first hour strike at the lead %v", c.lead)
+	}
+	lastQuarter := c.notes[firstStrike-1]
+	if lastQuarter.offset+westminsterBeat >= c.lead {
+		t.Errorf("expected a pause between the melody (last note at %v) and the hour strike", lastQuarter.offset)
+	}
+
+	// The melody of the full hour is changes 2, 3, 4 and 5
+	expected := []float64{
+		noteE4, noteGSharp4, noteFSharp4, noteB3,
+		noteE4, noteFSharp4, noteGSharp4, noteE4,
+		noteGSharp4, noteE4, noteFSharp4, noteB3,
+		noteB3, noteFSharp4, noteGSharp4, noteE4,
+	}
+	for i, frequency := range expected {
+		if c.notes[i].frequency != frequency {
+			t.Errorf("note %d: expected %.2f Hz, got %.2f Hz", i, frequency, c.notes[i].frequency)
+		}
+	}
+	// A rest after each change
+	if got := c.notes[4].offset - c.notes[3].offset; got != 2*westminsterBeat {
+		t.Errorf("expected a rest of a beat between changes, got %v between the notes", got)
+	}
+
+	// Strikes are evenly spaced
+	for i := firstStrike + 1; i < len(c.notes); i++ {
+		if got := c.notes[i].offset - c.notes[i-1].offset; got != strikeInterval {
+			t.Errorf("strike %d: expected %v after the previous one, got %v", i-firstStrike, strikeInterval, got)
+		}
+	}
+}
+
+func TestNextQuarterHour(t *testing.T) {
+	kathmandu := time.FixedZone("+0545", 5*60*60+45*60)
+	tests := []struct {
+		t        time.Time
+		expected time.Time
+	}{
+		{time.Date(2026, 10, 19, 9, 15, 0, 0, time.UTC), time.Date(2026, 10, 19, 9, 15, 0, 0, time.UTC)},
+		{time.Date(2026, 10, 19, 9, 15, 1, 0, time.UTC), time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC)},
+		{time.Date(2026, 10, 19, 23, 59, 44, 0, time.UTC), time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)},
+		// Quarter hours of the wall clock at UTC+05:45
+		{time.Date(2026, 10, 19, 9, 50, 0, 0, kathmandu), time.Date(2026, 10, 19, 10, 0, 0, 0, kathmandu)},
+	}
+
+	for _, tt := range tests {
+		if got := nextQuarterHour(tt.t); !got.Equal(tt.expected) {
+			t.Errorf("nextQuarterHour(%v): expected %v, got %v", tt.t, tt.expected, got)
+		}
+	}
+}
+
+func TestParseQuietHours(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected *QuietHours
+		err      bool
+	}{
+		{"", nil, false},
+		{"22:00-07:00", &QuietHours{22 * time.Hour, 7 * time.Hour}, false},
+		{"13:30-14:15", &QuietHours{13*time.Hour + 30*time.Minute, 14*time.Hour + 15*time.Minute}, false},
+		{"22-07", nil, true},
+		{"22:00-07:00junk", nil, true},
+		{"22:00-07:00 07:30", nil, true},
+		{"25:00-07:00", nil, true},
+		{"22:00-07:60", nil, true},
+		{"22:00-22:00", nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseQuietHours(tt.s)
+		if (err != nil) != tt.err {
+			t.Errorf("ParseQuietHours(%q): expected error %v, got %v", tt.s, tt.err, err)
+			continue
+		}
+		if (got == nil) != (tt.expected == nil) || got != nil && *got != *tt.expected {
+			t.Errorf("ParseQuietHours(%q): expected %v, got %v", tt.s, tt.expected, got)
+		}
+	}
+}
+
+func TestQuietHours_Contains(t *testing.T) {
+	overnight := &QuietHours{22 * time.Hour, 7 * time.Hour}
+	lunch := &QuietHours{12 * time.Hour, 13 * time.Hour}
+	tests := []struct {
+		quietHours *QuietHours
+		hour, min  int
+		expected   bool
+	}{
+		{overnight, 21, 45, false},
+		{overnight, 22, 0, true},
+		{overnight, 0, 0, true},
+		{overnight, 6, 45, true},
+		{overnight, 7, 0, false},
+		{lunch, 11, 45, false},
+		{lunch, 12, 30, true},
+		{lunch, 13, 0, false},
+		{nil, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		tm := time.Date(2026, 10, 19, tt.hour, tt.min, 0, 0, time.UTC)
+		if got := tt.quietHours.Contains(tm); got != tt.expected {
+			t.Errorf("%v Contains(%02d:%02d): expected %v, got %v", tt.quietHours, tt.hour, tt.min, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,343 @@
+package audio
+
+import (
+	"slices"
+	"time"
+
+	"chrono-ntp/clock"
+)
+
+// AllowedTimeSignals lists the time signals that beeps emulate
+var AllowedTimeSignals = [...]string{"greenwich", "wwv", "wwvh", "chu", "dcf77", "msf", "jjy"}
+
+// pulse is a tone of a time signal, relative to the start of its second
+type pulse struct {
+	offset    time.Duration
+	length    time.Duration
+	frequency float64
+}
+
+// timeSignal returns the pulses of the second starting at t
+type timeSignal func(t time.Time) []pulse
+
+var timeSignals = map[string]timeSignal{
+	"greenwich": greenwichSecond,
+	"wwv":       scheduledSignal(wwvSchedule(wwvTickFrequency, wwvTickFrequency), wwvSchedule(wwvTickFrequency, wwvHourFrequency)),
+	"wwvh":      scheduledSignal(wwvSchedule(wwvhTickFrequency, wwvhTickFrequency), wwvSchedule(wwvhTickFrequency, wwvHourFrequency)),
+	"chu":       scheduledSignal(chuMinute, chuHour),
+	"dcf77":     dcf77Second,
+	"msf":       msfSecond,
+	"jjy":       jjySecond,
+}
+
+const (
+	wwvTickFrequency  = 1000.0 // WWV, Fort Collins
+	wwvhTickFrequency = 1200.0 // WWVH, Kauai
+	wwvHourFrequency  = 1500.0
+	wwvTickLength     = 5 * time.Millisecond
+	wwvMarkerLength   = 800 * time.Millisecond
+)
+
+// greenwichSecond is the BBC Greenwich Time Signal: six pips from second 55,
+// the last one long
+func greenwichSecond(t time.Time) []pulse {
+	if !shouldBeep(t) {
+		return nil
+	}
+	if t.Second() == 0 {
+		return []pulse{{0, longMs * time.Millisecond, freq}}
+	}
+	return []pulse{{0, shortMs * time.Millisecond, freq}}
+}
+
+// scheduleEntry is the pulse of the seconds first to last of a minute, which
+// are silent for a length of 0
+type scheduleEntry struct {
+	first     int
+	last      int
+	length    time.Duration
+	frequency float64
+}
+
+// schedule is the cadence of the seconds of a minute. The first entry that
+// contains a second applies, so exceptions come before the ranges they cut.
+type schedule []scheduleEntry
+
+// pulses returns the pulse of the second in the schedule
+func (s schedule) pulses(second int) []pulse {
+	for _, entry := range s {
+		if second >= entry.first && second <= entry.last {
+			if entry.length == 0 {
+				return nil
+			}
+			return []pulse{{0, entry.length, entry.frequency}}
+		}
+	}
+	return nil
+}
+
+// scheduledSignal returns the time signal that plays the minute schedule,
+// and the hour schedule in the first minute of every hour (UTC)
+func scheduledSignal(minute schedule, hour schedule) timeSignal {
+	return func(t time.Time) []pulse {
+		t = t.UTC()
+		if t.Minute() == 0 {
+			return hour.pulses(t.Second())
+		}
+		return minute.pulses(t.Second())
+	}
+}
+
+// wwvSchedule returns the cadence of WWV or WWVH: a 5 ms tick every second
+// except seconds 29 and 59, and an 800 ms minute tone, which is 1500 Hz on the
+// hour
+// See: https://www.nist.gov/pml/time-and-frequency-division/time-distribution/radio-station-wwv
+func wwvSchedule(tickFrequency float64, markerFrequency float64) schedule {
+	return schedule{
+		{0, 0, wwvMarkerLength, markerFrequency},
+		{29, 29, 0, 0},
+		{59, 59, 0, 0},
+		{1, 58, wwvTickLength, tickFrequency},
+	}
+}
+
+// chuMinute is the cadence of CHU (Ottawa): 300 ms of 1000 Hz every second
+// and a 500 ms minute tone. Second 29 is silent, seconds 31 to 39 are
+// shortened to 10 ms for the time code, and seconds 51 to 59 are silent for
+// the voice announcement.
+// See: https://nrc.canada.ca/en/certifications-evaluations-standards/canadas-official-time/nrc-shortwave-station-broadcasts-chu
+var chuMinute = schedule{
+	{0, 0, 500 * time.Millisecond, freq},
+	{29, 29, 0, 0},
+	{31, 39, 10 * time.Millisecond, freq},
+	{51, 59, 0, 0},
+	{1, 50, 300 * time.Millisecond, freq},
+}
+
+// chuHour starts the hour with a 1 s tone followed by silence until second 10
+var chuHour = slices.Concat(schedule{
+	{0, 0, time.Second, freq},
+	{1, 9, 0, 0},
+}, chuMinute)
+
+// codeField is a field of a time code, with the weight of the bit of each
+// second from first. Bits of weight 0 are not part of the field. Values are
+// encoded by giving the bits to the largest weights first, which is BCD for
+// weights like 40, 20, 10, 8, 4, 2, 1 in any order.
+type codeField struct {
+	first   int
+	weights []int
+}
+
+// encode sets the bits of the value in the time code
+func (f codeField) encode(bits *[60]int, value int) {
+	seconds := make([]int, len(f.weights))
+	for i := range seconds {
+		seconds[i] = f.first + i
+	}
+	slices.SortStableFunc(seconds, func(a, b int) int { return f.weights[b-f.first] - f.weights[a-f.first] })
+	for _, second := range seconds {
+		if weight := f.weights[second-f.first]; weight > 0 && value >= weight {
+			bits[second] = 1
+			value -= weight
+		}
+	}
+}
+
+// parity returns the number of set bits of the seconds first to last modulo
+// 2, which is the even parity bit of the seconds
+func parity(bits *[60]int, first int, last int) int {
+	sum := 0
+	for _, bit := range bits[first : last+1] {
+		sum += bit
+	}
+	return sum % 2
+}
+
+// isEuropeanSummerTime reports whether summer time is in effect in the
+// European Union and the United Kingdom at t: from 01:00 UTC on the last
+// Sunday of March to 01:00 UTC on the last Sunday of October. The time code
+// signals follow these rules, and they do not need the time zone database.
+func isEuropeanSummerTime(t time.Time) bool {
+	t = t.UTC()
+	lastSunday := func(month time.Month) time.Time {
+		day := time.Date(t.Year(), month+1, 0, 1, 0, 0, 0, time.UTC)
+		return day.AddDate(0, 0, -int(day.Weekday()))
+	}
+	return !t.Before(lastSunday(time.March)) && t.Before(lastSunday(time.October))
+}
+
+// europeanTime returns t in central European (CET/CEST) or British (GMT/BST)
+// time
+func europeanTime(t time.Time, standardOffset int) time.Time {
+	if isEuropeanSummerTime(t) {
+		return t.In(time.FixedZone("", standardOffset+3600))
+	}
+	return t.In(time.FixedZone("", standardOffset))
+}
+
+// Fields of the DCF77 time code
+var (
+	dcf77Minute  = codeField{21, []int{1, 2, 4, 8, 10, 20, 40}}
+	dcf77Hour    = codeField{29, []int{1, 2, 4, 8, 10, 20}}
+	dcf77Day     = codeField{36, []int{1, 2, 4, 8, 10, 20}}
+	dcf77Weekday = codeField{42, []int{1, 2, 4}}
+	dcf77Month   = codeField{45, []int{1, 2, 4, 8, 10}}
+	dcf77Year    = codeField{50, []int{1, 2, 4, 8, 10, 20, 40, 80}}
+)
+
+// dcf77Code returns the bits of the DCF77 (Mainflingen) time code sent in the
+// minute starting at t, which is the central European time of the next
+// minute
+// See: https://en.wikipedia.org/wiki/DCF77#Time_code_interpretation
+func dcf77Code(t time.Time) [60]int {
+	next := europeanTime(t.Add(time.Minute), 3600)
+	var bits [60]int
+	// Summer time change at the end of this hour
+	if isEuropeanSummerTime(next) != isEuropeanSummerTime(next.Add(time.Hour).Truncate(time.Hour)) {
+		bits[16] = 1
+	}
+	if isEuropeanSummerTime(next) {
+		bits[17] = 1
+	} else {
+		bits[18] = 1
+	}
+	bits[20] = 1 // Start of the time
+	dcf77Minute.encode(&bits, next.Minute())
+	bits[28] = parity(&bits, 21, 27)
+	dcf77Hour.encode(&bits, next.Hour())
+	bits[35] = parity(&bits, 29, 34)
+	dcf77Day.encode(&bits, next.Day())
+	dcf77Weekday.encode(&bits, clock.ISOWeekday(next))
+	dcf77Month.encode(&bits, int(next.Month()))
+	dcf77Year.encode(&bits, next.Year()%100)
+	bits[58] = parity(&bits, 36, 57)
+	return bits
+}
+
+// dcf77Second returns the second marker of DCF77, where the carrier is
+// reduced for 100 ms for a 0 and 200 ms for a 1, as a receiver's beeper plays
+// it. Second 59 has no marker, so the next one is the minute marker.
+func dcf77Second(t time.Time) []pulse {
+	minute := t.Truncate(time.Minute)
+	if t.Second() == 59 {
+		return nil
+	}
+	bit := dcf77Code(minute)[t.Second()]
+	return []pulse{{0, time.Duration(bit+1) * 100 * time.Millisecond, freq}}
+}
+
+// Fields of the A bits of the MSF time code
+var (
+	msfYear    = codeField{17, []int{80, 40, 20, 10, 8, 4, 2, 1}}
+	msfMonth   = codeField{25, []int{10, 8, 4, 2, 1}}
+	msfDay     = codeField{30, []int{20, 10, 8, 4, 2, 1}}
+	msfWeekday = codeField{36, []int{4, 2, 1}}
+	msfHour    = codeField{39, []int{20, 10, 8, 4, 2, 1}}
+	msfMinute  = codeField{45, []int{40, 20, 10, 8, 4, 2, 1}}
+)
+
+// msfMinuteIdentifier are the A bits of seconds 52 to 59
+var msfMinuteIdentifier = [8]int{0, 1, 1, 1, 1, 1, 1, 0}
+
+// msfCode returns the A and B bits of the MSF (Anthorn) time code sent in the
+// minute starting at t, which is the UK time of the next minute. The parity
+// bits are odd parity.
+// See: https://en.wikipedia.org/wiki/Time_from_NPL_(MSF)
+func msfCode(t time.Time) (a [60]int, b [60]int) {
+	next := europeanTime(t.Add(time.Minute), 0)
+	msfYear.encode(&a, next.Year()%100)
+	msfMonth.encode(&a, int(next.Month()))
+	msfDay.encode(&a, next.Day())
+	msfWeekday.encode(&a, int(next.Weekday()))
+	msfHour.encode(&a, next.Hour())
+	msfMinute.encode(&a, next.Minute())
+	copy(a[52:], msfMinuteIdentifier[:])
+
+	// Summer time change within the next 61 minutes
+	if isEuropeanSummerTime(next) != isEuropeanSummerTime(next.Add(61*time.Minute)) {
+		b[53] = 1
+	}
+	b[54] = 1 - parity(&a, 17, 24)
+	b[55] = 1 - parity(&a, 25, 35)
+	b[56] = 1 - parity(&a, 36, 38)
+	b[57] = 1 - parity(&a, 39, 51)
+	if isEuropeanSummerTime(next) {
+		b[58] = 1
+	}
+	return a, b
+}
+
+// msfSecond returns the second marker of MSF, where the carrier is off for
+// 100 ms (A and B 0), 200 ms (A 1) or 300 ms (A and B 1), and for 500 ms at
+// the minute marker. The B bits of seconds 1 to 16 carry DUT1, which is left
+// at 0, so B is never 1 without A.
+func msfSecond(t time.Time) []pulse {
+	second := t.Second()
+	if second == 0 {
+		return []pulse{{0, 500 * time.Millisecond, freq}}
+	}
+	a, b := msfCode(t.Truncate(time.Minute))
+	return []pulse{{0, time.Duration(1+a[second]+b[second]) * 100 * time.Millisecond, freq}}
+}
+
+// Fields of the JJY time code. The day of the year spans the marker at second
+// 29.
+var (
+	jjyMinute    = codeField{1, []int{40, 20, 10, 0, 8, 4, 2, 1}}
+	jjyHour      = codeField{12, []int{20, 10, 0, 8, 4, 2, 1}}
+	jjyDayOfYear = codeField{22, []int{200, 100, 0, 80, 40, 20, 10, 0, 8, 4, 2, 1}}
+	jjyYear      = codeField{41, []int{80, 40, 20, 10, 8, 4, 2, 1}}
+	jjyWeekday   = codeField{50, []int{4, 2, 1}}
+)
+
+// jjyMarkers are the seconds of the position markers of JJY
+var jjyMarkers = []int{0, 9, 19, 29, 39, 49, 59}
+
+var japanStandardTime = time.FixedZone("JST", 9*60*60)
+
+// jjyCode returns the bits of the JJY (Japan) time code sent in the minute
+// starting at t, which is the Japan Standard Time of this minute. The parity
+// bits are even parity.
+// See: https://en.wikipedia.org/wiki/JJY#Timecode
+func jjyCode(t time.Time) [60]int {
+	t = t.In(japanStandardTime)
+	var bits [60]int
+	jjyMinute.encode(&bits, t.Minute())
+	jjyHour.encode(&bits, t.Hour())
+	jjyDayOfYear.encode(&bits, t.YearDay())
+	bits[36] = parity(&bits, 12, 18)
+	bits[37] = parity(&bits, 1, 8)
+	jjyYear.encode(&bits, t.Year()%100)
+	jjyWeekday.encode(&bits, int(t.Weekday()))
+	return bits
+}
+
+// jjySecond returns the second marker of JJY, where the carrier is high for
+// 800 ms for a 0, 500 ms for a 1 and 200 ms for a position marker
+func jjySecond(t time.Time) []pulse {
+	second := t.Second()
+	switch {
+	case slices.Contains(jjyMarkers, second):
+		return []pulse{{0, 200 * time.Millisecond, freq}}
+	case jjyCode(t.Truncate(time.Minute))[second] == 1:
+		return []pulse{{0, 500 * time.Millisecond, freq}}
+	default:
+		return []pulse{{0, 800 * time.Millisecond, freq}}
+	}
+}
+
+// renderPulses returns the samples of the pulses of a second, which start and
+// stop abruptly like the pulses of the broadcasts
+func renderPulses(pulses []pulse) []byte {
+	var length time.Duration
+	for _, p := range pulses {
+		length = max(length, p.offset+p.length)
+	}
+	buf := make([]byte, int(length*sampleRate/time.Second)*2)
+	for _, p := range pulses {
+		start := int(p.offset*sampleRate/time.Second) * 2
+		copy(buf[start:], makeSineWaveTableAt(p.frequency, int(p.length/time.Millisecond)))
+	}
+	return buf
+}
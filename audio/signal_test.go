@@ -0,0 +1,257 @@
+package audio
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// minuteTimeline returns the pulses of the time signal for every second of
+// the minute starting at t
+func minuteTimeline(signal string, t time.Time) [60][]pulse {
+	var timeline [60][]pulse
+	for second := range timeline {
+		timeline[second] = timeSignals[signal](t.Add(time.Duration(second) * time.Second))
+	}
+	return timeline
+}
+
+// pulseLengths returns the length of the pulses of each second in ms, and 0
+// for silent seconds
+func pulseLengths(timeline [60][]pulse) [60]int {
+	var lengths [60]int
+	for second, pulses := range timeline {
+		for _, p := range pulses {
+			lengths[second] += int(p.length / time.Millisecond)
+		}
+	}
+	return lengths
+}
+
+func bitString(bits []int) string {
+	var b strings.Builder
+	for _, bit := range bits {
+		b.WriteByte(byte('0' + bit))
+	}
+	return b.String()
+}
+
+func TestTimeSignals_AllowedSignals(t *testing.T) {
+	for _, signal := range AllowedTimeSignals {
+		if _, ok := timeSignals[signal]; !ok {
+			t.Errorf("time signal %q has no schedule", signal)
+		}
+	}
+}
+
+func TestGreenwichTimeline(t *testing.T) {
+	lengths := pulseLengths(minuteTimeline("greenwich", time.Date(2026, 10, 19, 14, 29, 0, 0, time.UTC)))
+	for second, length := range lengths {
+		expected := 0
+		switch {
+		case second == 0:
+			expected = longMs
+		case second >= 55:
+			expected = shortMs
+		}
+		if length != expected {
+			t.Errorf("second %d: expected %d ms, got %d ms", second, expected, length)
+		}
+	}
+}
+
+func TestWWVTimeline(t *testing.T) {
+	tests := []struct {
+		signal                    string
+		minute                    int
+		tickFrequency, markerFreq float64
+	}{
+		{"wwv", 29, 1000, 1000},
+		{"wwv", 0, 1000, 1500},
+		{"wwvh", 29, 1200, 1200},
+		{"wwvh", 0, 1200, 1500},
+	}
+
+	for _, tt := range tests {
+		timeline := minuteTimeline(tt.signal, time.Date(2026, 10, 19, 14, tt.minute, 0, 0, time.UTC))
+		for second, pulses := range timeline {
+			switch second {
+			case 29, 59:
+				if len(pulses) != 0 {
+					t.Errorf("%s minute %d second %d: expected no tick, got %v", tt.signal, tt.minute, second, pulses)
+				}
+			case 0:
+				if len(pulses) != 1 || pulses[0].length != 800*time.Millisecond || pulses[0].frequency != tt.markerFreq {
+					t.Errorf("%s minute %d second 0: expected an 800 ms tone of %g Hz, got %v", tt.signal, tt.minute, tt.markerFreq, pulses)
+				}
+			default:
+				if len(pulses) != 1 || pulses[0].length != 5*time.Millisecond || pulses[0].frequency != tt.tickFrequency {
+					t.Errorf("%s minute %d second %d: expected a 5 ms tick of %g Hz, got %v", tt.signal, tt.minute, second, tt.tickFrequency, pulses)
+				}
+			}
+		}
+	}
+}
+
+func TestCHUTimeline(t *testing.T) {
+	lengths := pulseLengths(minuteTimeline("chu", time.Date(2026, 10, 19, 14, 29, 0, 0, time.UTC)))
+	for second, length := range lengths {
+		expected := 300
+		switch {
+		case second == 0:
+			expected = 500
+		case second == 29, second > 50:
+			expected = 0
+		case second > 30 && second < 40:
+			expected = 10
+		}
+		if length != expected {
+			t.Errorf("second %d: expected %d ms, got %d ms", second, expected, length)
+		}
+	}
+
+	// The hour tone is followed by silence
+	hour := pulseLengths(minuteTimeline("chu", time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC)))
+	if hour[0] != 1000 || hour[1] != 0 || hour[9] != 0 || hour[10] != 300 {
+		t.Errorf("expected a 1 s hour tone and silence until second 10, got %v", hour[:11])
+	}
+}
+
+func TestDCF77Code(t *testing.T) {
+	// Sent from 14:29 CEST, for 14:30 CEST on Monday, 19 October 2026
+	code := dcf77Code(time.Date(2026, 10, 19, 12, 29, 0, 0, time.UTC))
+	expected := "00000000000000000" + "1001" + "0000110" + "0" + "001010" + "0" +
+		"100110" + "100" + "00001" + "01100100" + "0" + "0"
+	if got := bitString(code[:]); got != expected {
+		t.Errorf("expected\n%s, got\n%s", expected, got)
+	}
+
+	// CET, and the announcement in the hour before summer time ends
+	if code := dcf77Code(time.Date(2026, 12, 24, 12, 0, 0, 0, time.UTC)); code[17] != 0 || code[18] != 1 || code[16] != 0 {
+		t.Errorf("expected CET without announcement, got bits 16-18 %v", code[16:19])
+	}
+	if code := dcf77Code(time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC)); code[16] != 1 || code[17] != 1 {
+		t.Errorf("expected CEST with the announcement, got bits 16-18 %v", code[16:19])
+	}
+	// The minute before the change sends the time after it, 02:00 CET
+	if code := dcf77Code(time.Date(2026, 10, 25, 0, 59, 0, 0, time.UTC)); code[18] != 1 || bitString(code[29:35]) != "010000" {
+		t.Errorf("expected 02:00 CET, got bits 16-18 %v and hour %v", code[16:19], code[29:35])
+	}
+}
+
+func TestDCF77Timeline(t *testing.T) {
+	start := time.Date(2026, 10, 19, 12, 29, 0, 0, time.UTC)
+	code := dcf77Code(start)
+	lengths := pulseLengths(minuteTimeline("dcf77", start))
+	for second, length := range lengths {
+		expected := 100 + 100*code[second]
+		if second == 59 {
+			expected = 0
+		}
+		if length != expected {
+			t.Errorf("second %d: expected %d ms, got %d ms", second, expected, length)
+		}
+	}
+}
+
+func TestMSFCode(t *testing.T) {
+	// Sent from 14:29 BST, for 14:30 BST on Monday, 19 October 2026
+	a, b := msfCode(time.Date(2026, 10, 19, 13, 29, 0, 0, time.UTC))
+	expectedA := "0" + strings.Repeat("0", 16) + "00100110" + "10000" + "011001" + "001" + "010100" + "0110000" + "01111110"
+	if got := bitString(a[:]); got != expectedA {
+		t.Errorf("A bits: expected\n%s, got\n%s", expectedA, got)
+	}
+	// Odd parity of the year (3 bits set), month and day (4), weekday (1),
+	// hour and minute (4), and BST
+	if got := bitString(b[53:59]); got != "001011" {
+		t.Errorf("B bits 53-58: expected 001011, got %s", got)
+	}
+
+	// Summer time warning within 61 minutes of the change
+	if _, b := msfCode(time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC)); b[53] != 1 || b[58] != 1 {
+		t.Errorf("expected the summer time warning during BST, got B bits 53-58 %v", b[53:59])
+	}
+	if _, b := msfCode(time.Date(2026, 12, 24, 12, 0, 0, 0, time.UTC)); b[53] != 0 || b[58] != 0 {
+		t.Errorf("expected GMT without warning, got B bits 53-58 %v", b[53:59])
+	}
+}
+
+func TestMSFTimeline(t *testing.T) {
+	start := time.Date(2026, 10, 19, 13, 29, 0, 0, time.UTC)
+	a, b := msfCode(start)
+	lengths := pulseLengths(minuteTimeline("msf", start))
+	for second, length := range lengths {
+		expected := 100 * (1 + a[second] + b[second])
+		if second == 0 {
+			expected = 500
+		}
+		if length != expected {
+			t.Errorf("second %d: expected %d ms, got %d ms", second, expected, length)
+		}
+	}
+}
+
+func TestJJYCode(t *testing.T) {
+	// 14:30 JST on Monday, 19 October 2026, day 292 of the year
+	code := jjyCode(time.Date(2026, 10, 19, 5, 30, 0, 0, time.UTC))
+	expected := "0" + "01100000" + "0" + "00" + "01" + "0" + "0100" + "0" +
+		"00" + "10" + "0" + "1001" + "0" + "0010" + "00" + "00" + "0" + "0" +
+		"0" + "00100110" + "0" + "001" + "000000" + "0"
+	if got := bitString(code[:]); got != expected {
+		t.Errorf("expected\n%s, got\n%s", expected, got)
+	}
+
+	// Parity of the hour (23: three bits set) and minute (59: four bits set)
+	if code := jjyCode(time.Date(2026, 10, 19, 14, 59, 0, 0, time.UTC)); code[36] != 1 || code[37] != 0 {
+		t.Errorf("expected parity bits 1 and 0, got %v", code[36:38])
+	}
+}
+
+func TestJJYTimeline(t *testing.T) {
+	start := time.Date(2026, 10, 19, 5, 30, 0, 0, time.UTC)
+	code := jjyCode(start)
+	lengths := pulseLengths(minuteTimeline("jjy", start))
+	for second, length := range lengths {
+		expected := 800 - 300*code[second]
+		if second%10 == 9 || second == 0 {
+			expected = 200
+		}
+		if length != expected {
+			t.Errorf("second %d: expected %d ms, got %d ms", second, expected, length)
+		}
+	}
+}
+
+func TestIsEuropeanSummerTime(t *testing.T) {
+	tests := []struct {
+		t        time.Time
+		expected bool
+	}{
+		{time.Date(2026, 3, 29, 0, 59, 59, 0, time.UTC), false},
+		{time.Date(2026, 3, 29, 1, 0, 0, 0, time.UTC), true},
+		{time.Date(2026, 10, 25, 0, 59, 59, 0, time.UTC), true},
+		{time.Date(2026, 10, 25, 1, 0, 0, 0, time.UTC), false},
+		// Last Sunday of March 2027 is the 28th
+		{time.Date(2027, 3, 28, 1, 0, 0, 0, time.UTC), true},
+		{time.Date(2027, 3, 27, 12, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		if got := isEuropeanSummerTime(tt.t); got != tt.expected {
+			t.Errorf("isEuropeanSummerTime(%v): expected %v, got %v", tt.t, tt.expected, got)
+		}
+	}
+}
+
+func TestRenderPulses(t *testing.T) {
+	buf := renderPulses([]pulse{{0, 100 * time.Millisecond, 1000}, {200 * time.Millisecond, 100 * time.Millisecond, 1000}})
+	if expected := 300 * sampleRate / 1000 * 2; len(buf) != expected {
+		t.Errorf("expected %d bytes, got %d", expected, len(buf))
+	}
+	// Silent between the pulses
+	for i := 150 * sampleRate / 1000 * 2; i < 200*sampleRate/1000*2; i++ {
+		if buf[i] != 0 {
+			t.Fatalf("expected silence at byte %d, got %d", i, buf[i])
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package audio
+
+import (
+	"math"
+	"time"
+)
+
+// partial is an overtone of a voice, at a ratio of the note frequency
+type partial struct {
+	ratio     float64
+	amplitude float64
+}
+
+// voice describes the timbre of a synthesized instrument: the partials of
+// its sound and its envelope
+type voice struct {
+	partials []partial
+	attack   time.Duration // Rise from silence to full level
+	decay    time.Duration // Time constant of the exponential decay, 0 to sustain
+	release  time.Duration // Fade out at the end of the note, to avoid clicks
+	length   time.Duration // Length of a note
+}
+
+// note is a tone of a voice in a timeline, starting at the offset from the
+// start of the timeline
+type note struct {
+	offset    time.Duration
+	frequency float64
+	voice     *voice
+}
+
+// envelope returns the level (0-1) of the voice at the time since the start
+// of a note
+func (v *voice) envelope(t time.Duration) float64 {
+	if t < 0 || t >= v.length {
+		return 0
+	}
+	level := 1.0
+	if v.attack > 0 && t < v.attack {
+		level = float64(t) / float64(v.attack)
+	}
+	if v.decay > 0 {
+		level *= math.Exp(-float64(t) / float64(v.decay))
+	}
+	if remaining := v.length - t; v.release > 0 && remaining < v.release {
+		level *= float64(remaining) / float64(v.release)
+	}
+	return level
+}
+
+// timelineLength returns the time from the start of the timeline to the end
+// of its last note
+func timelineLength(notes []note) time.Duration {
+	var length time.Duration
+	for _, n := range notes {
+		length = max(length, n.offset+n.voice.length)
+	}
+	return length
+}
+
+// renderNotes mixes the notes into 16-bit samples like makeSineWaveTable, so
+// that notes ring on while the next ones start. Every note is a sum of sine
+// waves of its partials, shaped by the envelope of its voice. The mix is
+// clipped where many notes overlap.
+func renderNotes(notes []note) []byte {
+	numSamples := int(timelineLength(notes) * sampleRate / time.Second)
+	mix := make([]float64, numSamples)
+	for _, n := range notes {
+		total := 0.0
+		for _, p := range n.voice.partials {
+			total += p.amplitude
+		}
+		start := int(n.offset * sampleRate / time.Second)
+		end := min(start+int(n.voice.length*sampleRate/time.Second), numSamples)
+		for i := start; i < end; i++ {
+			t := float64(i-start) / float64(sampleRate)
+			level := n.voice.envelope(time.Duration(t * float64(time.Second)))
+			v := 0.0
+			for _, p := range n.voice.partials {
+				v += p.amplitude * math.Sin(2*math.Pi*n.frequency*p.ratio*t)
+			}
+			mix[i] += v / total * level
+		}
+	}
+
+	buf := make([]byte, numSamples*2) // 2 bytes per sample
+	for i, v := range mix {
+		s := int16(max(min(v*amplitude, 1), -1) * maxInt16)
+		buf[2*i] = byte(s)
+		buf[2*i+1] = byte(s >> 8)
+	}
+	return buf
+}
@@ -0,0 +1,69 @@
+package audio
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func sampleAt(buf []byte, d time.Duration) int16 {
+	i := int(d * sampleRate / time.Second)
+	return int16(buf[2*i]) | int16(buf[2*i+1])<<8
+}
+
+// peakAround returns the largest absolute sample in the 10 ms after d
+func peakAround(buf []byte, d time.Duration) float64 {
+	peak := 0.0
+	for offset := time.Duration(0); offset < 10*time.Millisecond; offset += time.Second / sampleRate {
+		peak = max(peak, math.Abs(float64(sampleAt(buf, d+offset))))
+	}
+	return peak
+}
+
+func TestVoiceEnvelope(t *testing.T) {
+	tests := []struct {
+		t        time.Duration
+		expected float64
+	}{
+		{-time.Millisecond, 0},
+		{0, 0},
+		{5 * time.Millisecond, math.Exp(-5.0 / 1200)},
+		{1200 * time.Millisecond, math.Exp(-1)},
+		// Release at the end of the note
+		{2950 * time.Millisecond, math.Exp(-2950.0/1200) / 2},
+		{3 * time.Second, 0},
+	}
+
+	for _, tt := range tests {
+		if got := quarterBell.envelope(tt.t); math.Abs(got-tt.expected) > 1e-9 {
+			t.Errorf("envelope(%v): expected %.6f, got %.6f", tt.t, tt.expected, got)
+		}
+	}
+}
+
+func TestRenderNotes(t *testing.T) {
+	notes := []note{{0, noteE4, &quarterBell}, {time.Second, noteB3, &quarterBell}}
+	buf := renderNotes(notes)
+
+	if expected := 4 * sampleRate * 2; len(buf) != expected {
+		t.Errorf("expected %d bytes (4 s), got %d", expected, len(buf))
+	}
+	for i := 0; i < len(buf); i += 2 {
+		v := int16(buf[i]) | int16(buf[i+1])<<8
+		if v < -maxInt16 || v > maxInt16 {
+			t.Fatalf("sample out of range: %d", v)
+		}
+	}
+
+	// The bell decays, and rings again when the second note is struck
+	start, ringing, struck := peakAround(buf, 10*time.Millisecond), peakAround(buf, 900*time.Millisecond), peakAround(buf, 1010*time.Millisecond)
+	if !(ringing < start/2) {
+		t.Errorf("expected the bell to decay, peak %.0f at the start and %.0f after 0.9 s", start, ringing)
+	}
+	if !(struck > ringing) {
+		t.Errorf("expected the second note to be louder than the decayed first, got %.0f and %.0f", struck, ringing)
+	}
+	if last := sampleAt(buf, 4*time.Second-time.Second/sampleRate); last < -2 || last > 2 {
+		t.Errorf("expected silence at the end of the release, got %d", last)
+	}
+}
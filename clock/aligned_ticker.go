@@ -0,0 +1,73 @@
+package clock
+
+import "time"
+
+// AlignedTicker delivers the corrected time (clock time minus offset) on C at
+// every multiple of step of the corrected time, e.g. exactly when the second
+// changes for a step of one second
+type AlignedTicker struct {
+	C        <-chan time.Time
+	stop     chan struct{}
+	clock    Clock
+	step     time.Duration
+	location *time.Location
+	offset   func() time.Duration
+}
+
+// NewAlignedTicker starts a ticker aligned to step boundaries of the time
+// corrected by offset. The offset function is called for every tick, so
+// changes of the offset (e.g. after an NTP refresh) are picked up.
+func NewAlignedTicker(clock Clock, step time.Duration, offset func() time.Duration) *AlignedTicker {
+	return NewAlignedTickerIn(clock, step, time.UTC, offset)
+}
+
+// NewAlignedTickerIn starts a ticker like NewAlignedTicker, with the step
+// boundaries counted from midnight in the location. This matters for steps
+// that do not divide an hour, such as the 0.864 second decimal second.
+func NewAlignedTickerIn(clock Clock, step time.Duration, location *time.Location, offset func() time.Duration) *AlignedTicker {
+	c := make(chan time.Time, 1)
+	t := &AlignedTicker{C: c, stop: make(chan struct{}), clock: clock, step: step, location: location, offset: offset}
+	go t.run(c)
+	return t
+}
+
+func (t *AlignedTicker) Stop() {
+	close(t.stop)
+}
+
+func (t *AlignedTicker) run(c chan<- time.Time) {
+	for {
+		now := t.clock.Now().Add(-t.offset())
+		next := NextBoundaryIn(now, t.step, t.location)
+		for now.Before(next) {
+			select {
+			case <-t.clock.After(next.Sub(now)):
+			case <-t.stop:
+				return
+			}
+			// The offset may have changed while waiting, so check again
+			now = t.clock.Now().Add(-t.offset())
+		}
+
+		select {
+		case c <- now:
+		case <-t.stop:
+			return
+		default: // Drop the tick if the receiver is too slow, like time.Ticker
+		}
+	}
+}
+
+// NextBoundary returns the first multiple of step (counted from the Unix
+// epoch) after t
+func NextBoundary(t time.Time, step time.Duration) time.Time {
+	return NextBoundaryIn(t, step, time.UTC)
+}
+
+// NextBoundaryIn returns the first multiple of step (counted from the Unix
+// epoch in the wall clock time of the location) after t
+func NextBoundaryIn(t time.Time, step time.Duration, location *time.Location) time.Time {
+	_, zoneOffset := t.In(location).Zone()
+	sinceEpoch := time.Duration(t.UnixNano()) + time.Duration(zoneOffset)*time.Second
+	return t.Add(step - sinceEpoch%step)
+}
@@ -0,0 +1,167 @@
+package clock
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeTimer struct {
+	d time.Duration
+	c chan time.Time
+}
+
+// fakeClock only advances when a timer is fired, by the duration of the timer
+// plus a latency which simulates a late timer
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	latency time.Duration
+	timers  chan fakeTimer
+}
+
+func newFakeClock(now time.Time, latency time.Duration) *fakeClock {
+	// The ticker waits for at most one timer at a time
+	return &fakeClock{now: now, latency: latency, timers: make(chan fakeTimer, 1)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	timer := fakeTimer{d: d, c: make(chan time.Time, 1)}
+	c.timers <- timer
+	return timer.c
+}
+
+// nextTick fires the timers the ticker waits for until it delivers a tick
+func (c *fakeClock) nextTick(ticker *AlignedTicker) time.Time {
+	for {
+		timer := <-c.timers
+		select {
+		case tick := <-ticker.C:
+			// The tick was sent before the ticker started waiting again
+			c.timers <- timer
+			return tick
+		default:
+		}
+
+		c.mu.Lock()
+		c.now = c.now.Add(timer.d + c.latency)
+		timer.c <- c.now
+		c.mu.Unlock()
+	}
+}
+
+func TestNextBoundary(t *testing.T) {
+	base := time.Date(2025, 11, 11, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		t        time.Time
+		step     time.Duration
+		expected time.Time
+	}{
+		{base, time.Second, base.Add(time.Second)},
+		{base.Add(1), time.Second, base.Add(time.Second)},
+		{base.Add(999 * time.Millisecond), time.Second, base.Add(time.Second)},
+		{base.Add(1234 * time.Millisecond), 100 * time.Millisecond, base.Add(1300 * time.Millisecond)},
+		{base.Add(1234 * time.Millisecond), 125 * time.Millisecond, base.Add(1250 * time.Millisecond)},
+	}
+
+	for _, tt := range tests {
+		if got := NextBoundary(tt.t, tt.step); !got.Equal(tt.expected) {
+			t.Errorf("NextBoundary(%v, %v): expected %v, got %v", tt.t, tt.step, tt.expected, got)
+		}
+	}
+}
+
+func TestAlignedTicker_PhaseError(t *testing.T) {
+	tests := []struct {
+		step   time.Duration
+		offset time.Duration
+	}{
+		{time.Second, 0},
+		{time.Second, 321 * time.Millisecond},
+		{time.Second, -47 * time.Millisecond},
+		{100 * time.Millisecond, 12345 * time.Microsecond},
+		{10 * time.Millisecond, 3 * time.Millisecond},
+	}
+	const latency = 2 * time.Millisecond
+	const ticks = 20
+
+	for _, tt := range tests {
+		clock := newFakeClock(time.Date(2025, 11, 11, 12, 0, 0, 437_000_000, time.UTC), latency)
+		ticker := NewAlignedTicker(clock, tt.step, func() time.Duration { return tt.offset })
+
+		previous := time.Time{}
+		for range ticks {
+			tick := clock.nextTick(ticker)
+			phaseError := time.Duration(tick.UnixNano()) % tt.step
+			if phaseError < 0 || phaseError > latency {
+				t.Errorf("step %v, offset %v: tick %v has phase error %v, expected at most %v", tt.step, tt.offset, tick, phaseError, latency)
+			}
+			if !previous.IsZero() && tick.Sub(previous) != tt.step {
+				t.Errorf("step %v, offset %v: expected ticks %v apart, got %v", tt.step, tt.offset, tt.step, tick.Sub(previous))
+			}
+			previous = tick
+		}
+		ticker.Stop()
+	}
+}
+
+func TestAlignedTicker_OffsetChange(t *testing.T) {
+	clock := newFakeClock(time.Date(2025, 11, 11, 12, 0, 0, 0, time.UTC), 0)
+	var mu sync.Mutex
+	offset := time.Duration(0)
+	ticker := NewAlignedTicker(clock, time.Second, func() time.Duration {
+		mu.Lock()
+		defer mu.Unlock()
+		return offset
+	})
+	defer ticker.Stop()
+
+	clock.nextTick(ticker)
+	mu.Lock()
+	offset = 600 * time.Millisecond
+	mu.Unlock()
+
+	for range 3 {
+		tick := clock.nextTick(ticker)
+		if tick.Nanosecond() != 0 {
+			t.Errorf("expected tick on the corrected second boundary, got %v", tick)
+		}
+		if raw := tick.Add(offset); raw.Nanosecond() != 600_000_000 {
+			t.Errorf("expected system clock time to be 600ms past the second, got %v", raw)
+		}
+	}
+}
+
+func TestNextBoundaryIn(t *testing.T) {
+	const decimalSecond = 864 * time.Millisecond
+	// Decimal seconds start at midnight, which is not a multiple of them in
+	// UTC for time zones with an offset of whole hours
+	paris := time.FixedZone("CET", 3600)
+	midnight := time.Date(2025, 11, 11, 0, 0, 0, 0, paris)
+	tests := []struct {
+		t        time.Time
+		step     time.Duration
+		location *time.Location
+		expected time.Time
+	}{
+		{midnight, decimalSecond, paris, midnight.Add(decimalSecond)},
+		{midnight.Add(-1), decimalSecond, paris, midnight},
+		{midnight.Add(100 * decimalSecond), decimalSecond, paris, midnight.Add(101 * decimalSecond)},
+		// 23:00 UTC is 95833⅓ decimal seconds after midnight UTC
+		{midnight.Add(-1), decimalSecond, time.UTC, midnight.Add(576 * time.Millisecond)},
+		// Steps that divide an hour do not depend on the location
+		{midnight.Add(1234 * time.Millisecond), 100 * time.Millisecond, paris, midnight.Add(1300 * time.Millisecond)},
+	}
+
+	for _, tt := range tests {
+		if got := NextBoundaryIn(tt.t, tt.step, tt.location); !got.Equal(tt.expected) {
+			t.Errorf("NextBoundaryIn(%v, %v, %v): expected %v, got %v", tt.t, tt.step, tt.location, tt.expected, got)
+		}
+	}
+}
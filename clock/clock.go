@@ -0,0 +1,23 @@
+package clock
+
+import "time"
+
+// Clock is the source of time for schedulers, which allows replacing the
+// system clock in tests
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+// System is the clock of the operating system
+var System Clock = systemClock{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
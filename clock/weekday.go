@@ -0,0 +1,9 @@
+package clock
+
+import "time"
+
+// ISOWeekday returns the ISO 8601 number of the weekday of t in its location
+// (1-7, Monday is 1)
+func ISOWeekday(t time.Time) int {
+	return (int(t.Weekday())+6)%7 + 1
+}
@@ -0,0 +1,25 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestISOWeekday(t *testing.T) {
+	tests := []struct {
+		date     time.Time
+		expected int
+	}{
+		{time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC), 1}, // Monday
+		{time.Date(2026, 10, 24, 12, 0, 0, 0, time.UTC), 6},
+		{time.Date(2026, 10, 25, 12, 0, 0, 0, time.UTC), 7}, // Sunday
+		// The weekday of the location, not of UTC
+		{time.Date(2026, 10, 25, 23, 30, 0, 0, time.FixedZone("", -3600)), 7},
+	}
+
+	for _, tt := range tests {
+		if got := ISOWeekday(tt.date); got != tt.expected {
+			t.Errorf("ISOWeekday(%v): expected %d, got %d", tt.date, tt.expected, got)
+		}
+	}
+}
@@ -9,17 +9,54 @@ import (
 
 const defaultNtpServer = "time.google.com"
 const defaultTimeFormat = "ISO8601"
+const defaultDateFormat = "YYYY-MM-DD"
 const defaultTimeZone = "Local"
+const defaultTheme = "default"
+const defaultMode = "digital"
+const defaultFirstWeekday = "monday"
+const defaultLocale = "en-US"
+const defaultDayCountPrecision = 5
+const defaultCalendar = "gregorian"
+const defaultChimes = "none"
+const defaultTimeSignal = "greenwich"
+
+type Colors struct {
+	Foreground string `toml:"foreground,omitempty"`
+	Background string `toml:"background,omitempty"`
+}
 
 type Configuration struct {
-	Server        string `toml:"server"`
-	TimeZone      string `toml:"time-zone"`
-	HideStatusBar bool   `toml:"hide-status-bar"`
-	HideDate      bool   `toml:"hide-date"`
-	ShowTimeZone  bool   `toml:"show-time-zone"`
-	TimeFormat    string `toml:"time-format"`
-	Beeps         bool   `toml:"beeps"`
-	Offline       bool   `toml:"offline"`
+	Server            string            `toml:"server"`
+	TimeZone          string            `toml:"time-zone"`
+	HideStatusBar     bool              `toml:"hide-status-bar"`
+	HideDate          bool              `toml:"hide-date"`
+	HealthBorder      bool              `toml:"health-border"`
+	ShowGraph         bool              `toml:"show-graph"`
+	ShowCalendar      bool              `toml:"show-calendar"`
+	FirstWeekday      string            `toml:"first-weekday"`
+	HolidaysFile      string            `toml:"holidays-file"`
+	ShowAstronomy     bool              `toml:"show-astronomy"`
+	Latitude          float64           `toml:"latitude"`
+	Longitude         float64           `toml:"longitude"`
+	ShowTimeZone      bool              `toml:"show-time-zone"`
+	Mode              string            `toml:"mode"`
+	DateFormat        string            `toml:"date-format"`
+	Calendar          string            `toml:"calendar"`
+	TimeFormat        string            `toml:"time-format"`
+	TimePrecision     int               `toml:"time-precision"`
+	DayCountPrecision int               `toml:"day-count-precision"`
+	MarsLocation      string            `toml:"mars-location"`
+	LeapSecondsFile   string            `toml:"leap-seconds-file"`
+	TickIndicator     bool              `toml:"tick-indicator"`
+	Beeps             bool              `toml:"beeps"`
+	TimeSignal        string            `toml:"time-signal"`
+	Chimes            string            `toml:"chimes"`
+	ChimeQuietHours   string            `toml:"chime-quiet-hours"`
+	Offline           bool              `toml:"offline"`
+	Theme             string            `toml:"theme"`
+	Locale            string            `toml:"locale"`
+	Colors            map[string]Colors `toml:"colors"`
+	KeyBindings       map[string]string `toml:"key-bindings"`
 }
 
 func getConfigurationContents(path string) ([]byte, error) {
@@ -35,14 +72,35 @@ func getConfigurationContents(path string) ([]byte, error) {
 
 func parseConfiguration(data []byte) (Configuration, error) {
 	config := Configuration{
-		Server:        defaultNtpServer,
-		TimeZone:      defaultTimeZone,
-		HideStatusBar: false,
-		HideDate:      false,
-		ShowTimeZone:  true,
-		TimeFormat:    defaultTimeFormat,
-		Beeps:         false,
-		Offline:       false,
+		Server:            defaultNtpServer,
+		TimeZone:          defaultTimeZone,
+		HideStatusBar:     false,
+		HideDate:          false,
+		HealthBorder:      false,
+		ShowGraph:         false,
+		ShowCalendar:      false,
+		FirstWeekday:      defaultFirstWeekday,
+		HolidaysFile:      "",
+		ShowAstronomy:     false,
+		Latitude:          0,
+		Longitude:         0,
+		ShowTimeZone:      true,
+		Mode:              defaultMode,
+		DateFormat:        defaultDateFormat,
+		Calendar:          defaultCalendar,
+		TimeFormat:        defaultTimeFormat,
+		TimePrecision:     0,
+		DayCountPrecision: defaultDayCountPrecision,
+		MarsLocation:      "",
+		LeapSecondsFile:   "",
+		TickIndicator:     false,
+		Beeps:             false,
+		TimeSignal:        defaultTimeSignal,
+		Chimes:            defaultChimes,
+		ChimeQuietHours:   "",
+		Offline:           false,
+		Theme:             defaultTheme,
+		Locale:            defaultLocale,
 	}
 
 	err := toml.Unmarshal(data, &config)
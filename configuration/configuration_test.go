@@ -22,18 +22,78 @@ func TestParseConfiguration_Defaults(t *testing.T) {
 	if config.HideDate != false {
 		t.Errorf("expected HideDate false, got %v", config.HideDate)
 	}
+	if config.HealthBorder != false {
+		t.Errorf("expected HealthBorder false, got %v", config.HealthBorder)
+	}
+	if config.ShowGraph != false {
+		t.Errorf("expected ShowGraph false, got %v", config.ShowGraph)
+	}
+	if config.ShowCalendar != false {
+		t.Errorf("expected ShowCalendar false, got %v", config.ShowCalendar)
+	}
+	if config.FirstWeekday != "monday" {
+		t.Errorf("expected FirstWeekday %q, got %q", "monday", config.FirstWeekday)
+	}
+	if config.HolidaysFile != "" {
+		t.Errorf("expected HolidaysFile %q, got %q", "", config.HolidaysFile)
+	}
+	if config.ShowAstronomy != false {
+		t.Errorf("expected ShowAstronomy false, got %v", config.ShowAstronomy)
+	}
+	if config.Latitude != 0 || config.Longitude != 0 {
+		t.Errorf("expected Latitude and Longitude 0, got %v, %v", config.Latitude, config.Longitude)
+	}
 	if config.ShowTimeZone != true {
 		t.Errorf("expected ShowTimeZone true, got %v", config.ShowTimeZone)
 	}
+	if config.Mode != "digital" {
+		t.Errorf("expected Mode %q, got %q", "digital", config.Mode)
+	}
+	if config.DateFormat != "YYYY-MM-DD" {
+		t.Errorf("expected DateFormat %q, got %q", "YYYY-MM-DD", config.DateFormat)
+	}
+	if config.MarsLocation != "" {
+		t.Errorf("expected MarsLocation %q, got %q", "", config.MarsLocation)
+	}
+	if config.Calendar != "gregorian" {
+		t.Errorf("expected Calendar %q, got %q", "gregorian", config.Calendar)
+	}
+	if config.LeapSecondsFile != "" {
+		t.Errorf("expected LeapSecondsFile %q, got %q", "", config.LeapSecondsFile)
+	}
 	if config.TimeFormat != "ISO8601" {
 		t.Errorf("expected TimeFormat %q, got %q", "ISO8601", config.TimeFormat)
 	}
+	if config.TimePrecision != 0 {
+		t.Errorf("expected TimePrecision 0, got %d", config.TimePrecision)
+	}
+	if config.DayCountPrecision != 5 {
+		t.Errorf("expected DayCountPrecision 5, got %d", config.DayCountPrecision)
+	}
+	if config.TickIndicator != false {
+		t.Errorf("expected TickIndicator false, got %v", config.TickIndicator)
+	}
 	if config.Beeps != false {
 		t.Errorf("expected Beeps false, got %v", config.Beeps)
 	}
+	if config.TimeSignal != "greenwich" {
+		t.Errorf("expected TimeSignal %q, got %q", "greenwich", config.TimeSignal)
+	}
+	if config.Chimes != "none" {
+		t.Errorf("expected Chimes %q, got %q", "none", config.Chimes)
+	}
+	if config.ChimeQuietHours != "" {
+		t.Errorf("expected ChimeQuietHours empty, got %q", config.ChimeQuietHours)
+	}
 	if config.Offline != false {
 		t.Errorf("expected Offline false, got %v", config.Offline)
 	}
+	if config.Theme != "default" {
+		t.Errorf("expected Theme %q, got %q", "default", config.Theme)
+	}
+	if config.Locale != "en-US" {
+		t.Errorf("expected Locale %q, got %q", "en-US", config.Locale)
+	}
 }
 
 func TestParseConfiguration_Content(t *testing.T) {
@@ -42,9 +102,28 @@ server = "pool.example-time-server.org"
 time-zone = "Europe/Berlin"
 hide-status-bar = true
 hide-date = true
+health-border = true
+show-graph = true
+show-calendar = true
+first-weekday = "sunday"
+holidays-file = "/etc/holidays.ics"
+show-astronomy = true
+latitude = 52.52
+longitude = -13.405
 show-time-zone = true
+mode = "analog"
+date-format = "YYYY-Www-D"
+calendar = "hebrew"
 time-format = "12h_AM_PM"
+mars-location = "curiosity"
+leap-seconds-file = "/usr/share/zoneinfo/leap-seconds.list"
+time-precision = 3
+day-count-precision = 8
+tick-indicator = true
 beeps = true
+time-signal = "dcf77"
+chimes = "westminster"
+chime-quiet-hours = "22:00-07:00"
 offline = true
 `
 	config, _ := parseConfiguration([]byte(tomlContent))
@@ -61,20 +140,122 @@ offline = true
 	if config.HideDate != true {
 		t.Errorf("expected HideDate true, got %v", config.HideDate)
 	}
+	if config.HealthBorder != true {
+		t.Errorf("expected HealthBorder true, got %v", config.HealthBorder)
+	}
+	if config.ShowGraph != true {
+		t.Errorf("expected ShowGraph true, got %v", config.ShowGraph)
+	}
+	if config.ShowCalendar != true {
+		t.Errorf("expected ShowCalendar true, got %v", config.ShowCalendar)
+	}
+	if config.FirstWeekday != "sunday" {
+		t.Errorf("expected FirstWeekday 'sunday', got %q", config.FirstWeekday)
+	}
+	if config.HolidaysFile != "/etc/holidays.ics" {
+		t.Errorf("expected HolidaysFile '/etc/holidays.ics', got %q", config.HolidaysFile)
+	}
+	if config.ShowAstronomy != true {
+		t.Errorf("expected ShowAstronomy true, got %v", config.ShowAstronomy)
+	}
+	if config.Latitude != 52.52 || config.Longitude != -13.405 {
+		t.Errorf("expected Latitude 52.52 and Longitude -13.405, got %v, %v", config.Latitude, config.Longitude)
+	}
 	if config.ShowTimeZone != true {
 		t.Errorf("expected ShowTimeZone true, got %v", config.ShowTimeZone)
 	}
+	if config.Mode != "analog" {
+		t.Errorf("expected Mode 'analog', got %q", config.Mode)
+	}
+	if config.DateFormat != "YYYY-Www-D" {
+		t.Errorf("expected DateFormat 'YYYY-Www-D', got %q", config.DateFormat)
+	}
+	if config.MarsLocation != "curiosity" {
+		t.Errorf("expected MarsLocation 'curiosity', got %q", config.MarsLocation)
+	}
+	if config.Calendar != "hebrew" {
+		t.Errorf("expected Calendar 'hebrew', got %q", config.Calendar)
+	}
+	if config.LeapSecondsFile != "/usr/share/zoneinfo/leap-seconds.list" {
+		t.Errorf("expected LeapSecondsFile '/usr/share/zoneinfo/leap-seconds.list', got %q", config.LeapSecondsFile)
+	}
 	if config.TimeFormat != "12h_AM_PM" {
 		t.Errorf("expected TimeFormat '12h_AM_PM', got %q", config.TimeFormat)
 	}
+	if config.TimePrecision != 3 {
+		t.Errorf("expected TimePrecision 3, got %d", config.TimePrecision)
+	}
+	if config.DayCountPrecision != 8 {
+		t.Errorf("expected DayCountPrecision 8, got %d", config.DayCountPrecision)
+	}
+	if config.TickIndicator != true {
+		t.Errorf("expected TickIndicator true, got %v", config.TickIndicator)
+	}
 	if config.Beeps != true {
 		t.Errorf("expected Beeps true, got %v", config.Beeps)
 	}
+	if config.TimeSignal != "dcf77" {
+		t.Errorf("expected TimeSignal 'dcf77', got %q", config.TimeSignal)
+	}
+	if config.Chimes != "westminster" {
+		t.Errorf("expected Chimes 'westminster', got %q", config.Chimes)
+	}
+	if config.ChimeQuietHours != "22:00-07:00" {
+		t.Errorf("expected ChimeQuietHours '22:00-07:00', got %q", config.ChimeQuietHours)
+	}
 	if config.Offline != true {
 		t.Errorf("expected Offline true, got %v", config.Offline)
 	}
 }
 
+func TestParseConfiguration_KeyBindings(t *testing.T) {
+	tomlContent := `
+[key-bindings]
+quit = "x"
+help = "h"
+`
+	config, err := parseConfiguration([]byte(tomlContent))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]string{"quit": "x", "help": "h"}
+	if !reflect.DeepEqual(config.KeyBindings, expected) {
+		t.Errorf("expected KeyBindings %v, got %v", expected, config.KeyBindings)
+	}
+}
+
+func TestParseConfiguration_Colors(t *testing.T) {
+	tomlContent := `
+theme = "amber"
+locale = "de-DE"
+
+[colors.time]
+foreground = "#ffcc00"
+
+[colors.background]
+background = "black"
+`
+	config, err := parseConfiguration([]byte(tomlContent))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Theme != "amber" {
+		t.Errorf("expected Theme 'amber', got %q", config.Theme)
+	}
+	if config.Locale != "de-DE" {
+		t.Errorf("expected Locale 'de-DE', got %q", config.Locale)
+	}
+	expected := map[string]Colors{
+		"time":       {Foreground: "#ffcc00"},
+		"background": {Background: "black"},
+	}
+	if !reflect.DeepEqual(config.Colors, expected) {
+		t.Errorf("expected Colors %v, got %v", expected, config.Colors)
+	}
+}
+
 func TestLoadConfiguration(t *testing.T) {
 	// Create a temporary directory to act as HOME
 	tempDir, err := os.MkdirTemp("", "chrono-ntp-test-home")
@@ -171,14 +352,34 @@ func TestWriteConfiguration(t *testing.T) {
 
 	configPath := filepath.Join(tempDir, ".chrono-ntp.toml")
 	config := Configuration{
-		Server:        "write.test.server",
-		TimeZone:      "Mars/Colony",
-		HideStatusBar: true,
-		HideDate:      true,
-		ShowTimeZone:  false,
-		TimeFormat:    "mars",
-		Beeps:         true,
-		Offline:       true,
+		Server:            "write.test.server",
+		TimeZone:          "Mars/Colony",
+		HideStatusBar:     true,
+		HideDate:          true,
+		HealthBorder:      true,
+		ShowGraph:         true,
+		ShowCalendar:      true,
+		FirstWeekday:      "sunday",
+		HolidaysFile:      "holidays.ics",
+		ShowAstronomy:     true,
+		Latitude:          -33.8688,
+		Longitude:         151.2093,
+		ShowTimeZone:      false,
+		Mode:              "analog",
+		DateFormat:        "custom:%A, %d %B %Y",
+		Calendar:          "japanese",
+		TimeFormat:        "mars",
+		TimePrecision:     2,
+		DayCountPrecision: 3,
+		MarsLocation:      "-45.5",
+		LeapSecondsFile:   "leap-seconds.list",
+		TickIndicator:     true,
+		Beeps:             true,
+		TimeSignal:        "jjy",
+		Chimes:            "cuckoo",
+		ChimeQuietHours:   "12:00-13:00",
+		Offline:           true,
+		Locale:            "ja-JP",
 	}
 
 	configPathResult, err := WriteConfiguration(config)
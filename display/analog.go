@@ -0,0 +1,94 @@
+package display
+
+import (
+	"math"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+var AllowedModes = [...]string{"digital", "analog"}
+
+const (
+	analogHourHandLength   = 0.5
+	analogMinuteHandLength = 0.75
+	analogSecondHandLength = 0.85
+	analogHourMarkLength   = 0.1
+	analogMinimumRadius    = 4 // in dots, smaller faces are not drawn
+)
+
+// NextMode returns the display mode following the given one, wrapping around
+func NextMode(mode string) string {
+	return nextFormat(AllowedModes[:], mode)
+}
+
+// handAngles returns the angles (in radians, clockwise from 12 o'clock) of
+// the hour, minute and second hands. The hour and minute hands move
+// continuously, the second hand jumps once per second.
+func handAngles(t time.Time) (hour float64, minute float64, second float64) {
+	h, m, s := t.Clock()
+	second = float64(s) / 60
+	minute = (float64(m) + second) / 60
+	hour = (float64(h%12) + minute) / 12
+	return hour * 2 * math.Pi, minute * 2 * math.Pi, second * 2 * math.Pi
+}
+
+// pointOnFace returns the dot at the given angle and distance (as a fraction
+// of the radius) from the center of the face
+func pointOnFace(cx int, cy int, radius int, angle float64, distance float64) (int, int) {
+	r := float64(radius) * distance
+	return cx + int(math.Round(r*math.Sin(angle))), cy - int(math.Round(r*math.Cos(angle)))
+}
+
+// drawAnalogClock draws a clock face with hour, minute and second hands into
+// the area between the rows top and bottom (exclusive), scaled to the
+// largest circle that fits. Braille dots are roughly square, so the circle
+// stays round on common terminal fonts.
+func drawAnalogClock(screen tcell.Screen, top int, bottom int, t time.Time, faceStyle tcell.Style, secondHandStyle tcell.Style) {
+	width, _ := screen.Size()
+	diameter := min(width*2, (bottom-top)*4) - 1
+	radius := diameter / 2
+	if radius < analogMinimumRadius {
+		return
+	}
+
+	cellsWide := (diameter + 2) / 2
+	cellsHigh := (diameter + 4) / 4
+	face := newBrailleCanvas(cellsWide, cellsHigh)
+	secondHand := newBrailleCanvas(cellsWide, cellsHigh)
+	cx, cy := radius, radius
+
+	// Outline, with enough steps to leave no gaps between dots
+	steps := int(2 * math.Pi * float64(radius) * 2)
+	for i := range steps {
+		face.set(pointOnFace(cx, cy, radius, 2*math.Pi*float64(i)/float64(steps), 1))
+	}
+
+	for hour := range 12 {
+		angle := 2 * math.Pi * float64(hour) / 12
+		x0, y0 := pointOnFace(cx, cy, radius, angle, 1-analogHourMarkLength)
+		x1, y1 := pointOnFace(cx, cy, radius, angle, 1)
+		face.line(x0, y0, x1, y1)
+	}
+
+	hourAngle, minuteAngle, secondAngle := handAngles(t)
+	hands := []struct {
+		canvas *brailleCanvas
+		angle  float64
+		length float64
+	}{
+		{face, hourAngle, analogHourHandLength},
+		{face, minuteAngle, analogMinuteHandLength},
+		{secondHand, secondAngle, analogSecondHandLength},
+	}
+	for _, hand := range hands {
+		x, y := pointOnFace(cx, cy, radius, hand.angle, hand.length)
+		hand.canvas.line(cx, cy, x, y)
+	}
+
+	left := (width - cellsWide) / 2
+	top += (bottom - top - cellsHigh) / 2
+	face.overlay(secondHand)
+	secondHand.draw(screen, left, top, secondHandStyle)
+	face.draw(screen, left, top, faceStyle)
+}
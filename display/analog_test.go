@@ -0,0 +1,100 @@
+package display
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestHandAngles(t *testing.T) {
+	tests := []struct {
+		time                 time.Time
+		hour, minute, second float64
+	}{
+		{time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), 0, 0, 0},
+		{time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), 0, 0, 0},
+		{time.Date(2025, 1, 1, 3, 0, 0, 0, time.UTC), math.Pi / 2, 0, 0},
+		{time.Date(2025, 1, 1, 18, 30, 0, 0, time.UTC), 13 * math.Pi / 12, math.Pi, 0},
+		{time.Date(2025, 1, 1, 0, 0, 15, 999_000_000, time.UTC), math.Pi / 1440, math.Pi / 120, math.Pi / 2},
+	}
+
+	for _, tt := range tests {
+		hour, minute, second := handAngles(tt.time)
+		if math.Abs(hour-tt.hour) > 1e-9 || math.Abs(minute-tt.minute) > 1e-9 || math.Abs(second-tt.second) > 1e-9 {
+			t.Errorf("handAngles(%v): expected %v, %v, %v, got %v, %v, %v", tt.time, tt.hour, tt.minute, tt.second, hour, minute, second)
+		}
+	}
+}
+
+func TestPointOnFace(t *testing.T) {
+	tests := []struct {
+		angle    float64
+		distance float64
+		x, y     int
+	}{
+		{0, 1, 10, 0},
+		{math.Pi / 2, 1, 20, 10},
+		{math.Pi, 0.5, 10, 15},
+		{3 * math.Pi / 2, 1, 0, 10},
+	}
+
+	for _, tt := range tests {
+		x, y := pointOnFace(10, 10, 10, tt.angle, tt.distance)
+		if x != tt.x || y != tt.y {
+			t.Errorf("pointOnFace(%v, %v): expected %d, %d, got %d, %d", tt.angle, tt.distance, tt.x, tt.y, x, y)
+		}
+	}
+}
+
+func TestDrawAnalogClock(t *testing.T) {
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("failed to initialize screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(40, 12)
+
+	// At 06:00:00 all hands are vertical, which puts them in the center column
+	drawAnalogClock(screen, 0, 12, time.Date(2025, 1, 1, 6, 0, 0, 0, time.UTC), tcell.StyleDefault, tcell.StyleDefault)
+
+	drawn := 0
+	for y := range 12 {
+		for x := range 40 {
+			r, _, _, _ := screen.GetContent(x, y)
+			if r >= brailleBlank && r <= brailleBlank+0xff {
+				drawn++
+			} else if r != ' ' {
+				t.Errorf("unexpected rune %q at %d, %d", r, x, y)
+			}
+		}
+	}
+	if drawn == 0 {
+		t.Fatalf("expected clock face to be drawn")
+	}
+
+	// Minute hand points up, hour hand down from the center (row 5)
+	for _, y := range []int{2, 4, 7, 8} {
+		if r, _, _, _ := screen.GetContent(19, y); r == ' ' {
+			t.Errorf("expected hand in center column at row %d", y)
+		}
+	}
+}
+
+func TestDrawAnalogClock_TooSmall(t *testing.T) {
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("failed to initialize screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(40, 12)
+
+	drawAnalogClock(screen, 5, 6, time.Now(), tcell.StyleDefault, tcell.StyleDefault)
+
+	for x := range 40 {
+		if r, _, _, _ := screen.GetContent(x, 5); r != ' ' {
+			t.Fatalf("expected nothing drawn, got %q at %d", r, x)
+		}
+	}
+}
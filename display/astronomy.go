@@ -0,0 +1,53 @@
+package display
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"chrono-ntp/astronomy"
+)
+
+const astronomyRows = 3
+
+// formatEventTime returns the clock time of a solar event, or a dash if it
+// does not happen on the day
+func formatEventTime(t time.Time) string {
+	if t.IsZero() {
+		return "--:--"
+	}
+	return t.Format("15:04")
+}
+
+// formatTwilight returns the start and end of a twilight, or "none" if the
+// sun does not get low enough (e.g. during summer at high latitudes)
+func formatTwilight(dawn time.Time, dusk time.Time) string {
+	if dawn.IsZero() && dusk.IsZero() {
+		return "none"
+	}
+	return formatEventTime(dawn) + "–" + formatEventTime(dusk)
+}
+
+// astronomyLines returns the solar events of the day of now at the given
+// latitude and longitude, and the current moon phase
+func astronomyLines(now time.Time, latitude float64, longitude float64) []string {
+	sun := astronomy.SunTimesOn(now, latitude, longitude)
+	moon := astronomy.MoonPhaseAt(now)
+	return []string{
+		fmt.Sprintf("Sunrise %s  Solar Noon %s  Sunset %s",
+			formatEventTime(sun.Sunrise), formatEventTime(sun.SolarNoon), formatEventTime(sun.Sunset)),
+		fmt.Sprintf("Civil %s  Nautical %s  Astronomical %s",
+			formatTwilight(sun.CivilDawn, sun.CivilDusk),
+			formatTwilight(sun.NauticalDawn, sun.NauticalDusk),
+			formatTwilight(sun.AstronomicalDawn, sun.AstronomicalDusk)),
+		fmt.Sprintf("%s, %d%% illuminated", moon.Name, int(math.Round(moon.Illumination*100))),
+	}
+}
+
+func drawAstronomy(screen tcell.Screen, y int, now time.Time, latitude float64, longitude float64, style tcell.Style) {
+	for i, line := range astronomyLines(now, latitude, longitude) {
+		drawTextCentered(screen, y+i, line, style)
+	}
+}
@@ -0,0 +1,44 @@
+package display
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAstronomyLines(t *testing.T) {
+	london := mustLoadLocation("Europe/London")
+	lines := astronomyLines(time.Date(2023, 6, 21, 12, 0, 0, 0, london), 51.5074, -0.1278)
+
+	expected := []string{
+		"Sunrise 04:42  Solar Noon 13:02  Sunset 21:21",
+		"Civil 03:55–22:09  Nautical 02:40–23:23  Astronomical none",
+		"Waxing Crescent, 10% illuminated",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("expected %d lines, got %d", len(expected), len(lines))
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("line %d: expected '%s', got '%s'", i, expected[i], lines[i])
+		}
+	}
+}
+
+func TestFormatTwilight(t *testing.T) {
+	dawn := time.Date(2023, 12, 21, 9, 31, 0, 0, time.UTC)
+	dusk := time.Date(2023, 12, 21, 13, 53, 0, 0, time.UTC)
+	tests := []struct {
+		dawn, dusk time.Time
+		expected   string
+	}{
+		{dawn, dusk, "09:31–13:53"},
+		{time.Time{}, dusk, "--:--–13:53"},
+		{time.Time{}, time.Time{}, "none"},
+	}
+
+	for _, tt := range tests {
+		if got := formatTwilight(tt.dawn, tt.dusk); got != tt.expected {
+			t.Errorf("formatTwilight(%v, %v): expected '%s', got '%s'", tt.dawn, tt.dusk, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package display
+
+import "github.com/gdamore/tcell/v2"
+
+// brailleDotBits maps the position of a dot within a braille cell (2 columns,
+// 4 rows) to its bit in the Unicode braille pattern block
+// See: https://en.wikipedia.org/wiki/Braille_Patterns
+var brailleDotBits = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+const brailleBlank = 0x2800
+
+// brailleCanvas is a drawing surface of dots, where every terminal cell holds
+// 2x4 dots, which gives eight times the resolution of the cells
+type brailleCanvas struct {
+	width  int // in cells
+	height int // in cells
+	cells  []rune
+}
+
+func newBrailleCanvas(width int, height int) *brailleCanvas {
+	return &brailleCanvas{width: width, height: height, cells: make([]rune, width*height)}
+}
+
+// set sets the dot at x, y (in dots), dots outside of the canvas are ignored
+func (c *brailleCanvas) set(x int, y int) {
+	if x < 0 || y < 0 || x >= c.width*2 || y >= c.height*4 {
+		return
+	}
+	c.cells[(y/4)*c.width+x/2] |= brailleDotBits[y%4][x%2]
+}
+
+// line draws a line between two dots (Bresenham's line algorithm)
+func (c *brailleCanvas) line(x0 int, y0 int, x1 int, y1 int) {
+	dx := abs(x1 - x0)
+	dy := -abs(y1 - y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx + dy
+	for {
+		c.set(x0, y0)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+// overlay adds the dots of another canvas of the same size to the cells
+// which already have dots set, so drawing this canvas on top of the other one
+// keeps the dots of both
+func (c *brailleCanvas) overlay(other *brailleCanvas) {
+	for i, bits := range c.cells {
+		if bits != 0 {
+			c.cells[i] |= other.cells[i]
+		}
+	}
+}
+
+// cell returns the braille rune of the cell at x, y (in cells), or 0 if no
+// dot of the cell is set
+func (c *brailleCanvas) cell(x int, y int) rune {
+	if bits := c.cells[y*c.width+x]; bits != 0 {
+		return brailleBlank + bits
+	}
+	return 0
+}
+
+// draw draws the cells with dots set at the given screen position
+func (c *brailleCanvas) draw(screen tcell.Screen, left int, top int, style tcell.Style) {
+	for y := range c.height {
+		for x := range c.width {
+			if r := c.cell(x, y); r != 0 {
+				screen.SetContent(left+x, top+y, r, nil, style)
+			}
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,67 @@
+package display
+
+import "testing"
+
+func TestBrailleCanvas_Set(t *testing.T) {
+	tests := []struct {
+		x, y     int
+		expected rune
+	}{
+		{0, 0, '⠁'},
+		{1, 0, '⠈'},
+		{0, 2, '⠄'},
+		{0, 3, '⡀'},
+		{1, 3, '⢀'},
+	}
+
+	for _, tt := range tests {
+		c := newBrailleCanvas(1, 1)
+		c.set(tt.x, tt.y)
+		if got := c.cell(0, 0); got != tt.expected {
+			t.Errorf("set(%d, %d): expected %q, got %q", tt.x, tt.y, tt.expected, got)
+		}
+	}
+}
+
+func TestBrailleCanvas_SetOutside(t *testing.T) {
+	c := newBrailleCanvas(1, 1)
+	c.set(-1, 0)
+	c.set(2, 0)
+	c.set(0, 4)
+	if got := c.cell(0, 0); got != 0 {
+		t.Errorf("expected empty cell, got %q", got)
+	}
+}
+
+func TestBrailleCanvas_Line(t *testing.T) {
+	c := newBrailleCanvas(2, 1)
+	c.line(0, 0, 3, 3)
+	if got := c.cell(0, 0); got != '⠑' {
+		t.Errorf("expected first cell '⠑', got %q", got)
+	}
+	if got := c.cell(1, 0); got != '⢄' {
+		t.Errorf("expected second cell '⢄', got %q", got)
+	}
+
+	c = newBrailleCanvas(1, 1)
+	c.line(0, 3, 0, 0)
+	if got := c.cell(0, 0); got != '⡇' {
+		t.Errorf("expected vertical line '⡇', got %q", got)
+	}
+}
+
+func TestBrailleCanvas_Overlay(t *testing.T) {
+	c := newBrailleCanvas(2, 1)
+	other := newBrailleCanvas(2, 1)
+	c.set(0, 0)
+	other.set(1, 0)
+	other.set(2, 0)
+	c.overlay(other)
+
+	if got := c.cell(0, 0); got != '⠉' {
+		t.Errorf("expected dots of both canvases, got %q", got)
+	}
+	if got := c.cell(1, 0); got != 0 {
+		t.Errorf("expected empty cell to stay empty, got %q", got)
+	}
+}
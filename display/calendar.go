@@ -0,0 +1,109 @@
+package display
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"chrono-ntp/holidays"
+	"chrono-ntp/locale"
+)
+
+const (
+	calendarWeeks = 6 // Always drawn, so the layout does not change between months
+	calendarWidth = 23
+	// Title, weekday names, weeks and the holiday of the day
+	calendarRows = calendarWeeks + 3
+
+	calendarWeekLabel = "Wk"
+)
+
+type calendarWeek struct {
+	number int          // ISO 8601 week number
+	days   [7]time.Time // Zero for days outside of the month
+}
+
+// ParseWeekday returns the weekday with the given English name (e.g.
+// "monday"), ignoring case
+func ParseWeekday(name string) (time.Weekday, error) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(weekday.String(), name) {
+			return weekday, nil
+		}
+	}
+	return time.Sunday, fmt.Errorf("invalid weekday '%s'", name)
+}
+
+// calendarWeeksOf returns the weeks of the month of t, starting on the first
+// weekday. Weeks are numbered by their Thursday, which is the ISO 8601 week
+// for weeks starting on Monday and the week most days belong to otherwise.
+func calendarWeeksOf(t time.Time, firstWeekday time.Weekday) []calendarWeek {
+	year, month, _ := t.Date()
+	// Noon avoids skipping or repeating days around daylight saving time changes
+	first := time.Date(year, month, 1, 12, 0, 0, 0, t.Location())
+	start := first.AddDate(0, 0, -((int(first.Weekday()) - int(firstWeekday) + 7) % 7))
+
+	weeks := []calendarWeek{}
+	for day := start; day.Month() == month || day.Before(first); {
+		week := calendarWeek{}
+		for i := range week.days {
+			if day.Weekday() == time.Thursday {
+				_, week.number = day.ISOWeek()
+			}
+			if day.Month() == month {
+				week.days[i] = day
+			}
+			day = day.AddDate(0, 0, 1)
+		}
+		weeks = append(weeks, week)
+	}
+	return weeks
+}
+
+// weekdayHeader returns the localized abbreviations of the weekdays, each two
+// cells wide, after the label of the week number column
+func weekdayHeader(firstWeekday time.Weekday, l *locale.Locale) string {
+	names := []string{padLeft(l.Label(calendarWeekLabel), 2)}
+	for i := range 7 {
+		names = append(names, padLeft(l.ShortWeekday((firstWeekday+time.Weekday(i))%7), 2))
+	}
+	return strings.Join(names, " ")
+}
+
+// drawCalendar draws the month of now starting at row y, with today and
+// holidays highlighted and the name of today's holiday below the month
+func drawCalendar(screen tcell.Screen, y int, now time.Time, firstWeekday time.Weekday, h *holidays.Holidays, l *locale.Locale, style tcell.Style) {
+	width, _ := screen.Size()
+	left := (width - calendarWidth) / 2
+
+	drawTextCentered(screen, y, l.MonthYear(now), style.Bold(true))
+	drawText(screen, left, y+1, weekdayHeader(firstWeekday, l), style.Bold(true))
+
+	for row, week := range calendarWeeksOf(now, firstWeekday) {
+		drawText(screen, left, y+2+row, fmt.Sprintf("%2d", week.number), style.Dim(true))
+		for i, day := range week.days {
+			if day.IsZero() {
+				continue
+			}
+			dayStyle := style
+			if _, isHoliday := h.Lookup(day); isHoliday {
+				dayStyle = dayStyle.Underline(true).Bold(true)
+			}
+			if day.Day() == now.Day() {
+				dayStyle = dayStyle.Reverse(true)
+			}
+			drawText(screen, left+3*(i+1), y+2+row, fmt.Sprintf("%2d", day.Day()), dayStyle)
+		}
+	}
+
+	if summary, isHoliday := h.Lookup(now); isHoliday {
+		drawTextCentered(screen, y+2+calendarWeeks, summary, style)
+	}
+}
+
+// padLeft pads the text with spaces to the given number of cells
+func padLeft(text string, width int) string {
+	return strings.Repeat(" ", max(width-textWidth(text), 0)) + text
+}
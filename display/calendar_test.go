@@ -0,0 +1,169 @@
+package display
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"chrono-ntp/holidays"
+	"chrono-ntp/locale"
+)
+
+func TestParseWeekday(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected time.Weekday
+	}{
+		{"monday", time.Monday},
+		{"Sunday", time.Sunday},
+		{"SATURDAY", time.Saturday},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseWeekday(tt.name)
+		if err != nil || got != tt.expected {
+			t.Errorf("ParseWeekday(%q): expected %v, got %v (%v)", tt.name, tt.expected, got, err)
+		}
+	}
+
+	if _, err := ParseWeekday("mon"); err == nil {
+		t.Errorf("expected error for abbreviated weekday")
+	}
+}
+
+func TestCalendarWeeksOf(t *testing.T) {
+	tests := []struct {
+		date         time.Time
+		firstWeekday time.Weekday
+		weekNumbers  []int
+		firstRow     [7]int
+		lastRow      [7]int
+	}{
+		// October 2023 starts on a Sunday
+		{time.Date(2023, 10, 15, 0, 0, 0, 0, time.UTC), time.Monday, []int{39, 40, 41, 42, 43, 44}, [7]int{0, 0, 0, 0, 0, 0, 1}, [7]int{30, 31, 0, 0, 0, 0, 0}},
+		{time.Date(2023, 10, 15, 0, 0, 0, 0, time.UTC), time.Sunday, []int{40, 41, 42, 43, 44}, [7]int{1, 2, 3, 4, 5, 6, 7}, [7]int{29, 30, 31, 0, 0, 0, 0}},
+		// February 2021 fits into exactly four weeks starting on Monday
+		{time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC), time.Monday, []int{5, 6, 7, 8}, [7]int{1, 2, 3, 4, 5, 6, 7}, [7]int{22, 23, 24, 25, 26, 27, 28}},
+		// January 2021 starts in week 53 of 2020
+		{time.Date(2021, 1, 31, 0, 0, 0, 0, time.UTC), time.Monday, []int{53, 1, 2, 3, 4}, [7]int{0, 0, 0, 0, 1, 2, 3}, [7]int{25, 26, 27, 28, 29, 30, 31}},
+		// Daylight saving time ends on the last Sunday of October in Europe
+		{time.Date(2023, 10, 29, 2, 30, 0, 0, mustLoadLocation("Europe/Berlin")), time.Saturday, []int{40, 41, 42, 43, 44}, [7]int{0, 1, 2, 3, 4, 5, 6}, [7]int{28, 29, 30, 31, 0, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		weeks := calendarWeeksOf(tt.date, tt.firstWeekday)
+		numbers := []int{}
+		for _, week := range weeks {
+			numbers = append(numbers, week.number)
+		}
+		if !slices.Equal(numbers, tt.weekNumbers) {
+			t.Errorf("calendarWeeksOf(%v, %v): expected weeks %v, got %v", tt.date, tt.firstWeekday, tt.weekNumbers, numbers)
+			continue
+		}
+		if got := dayNumbers(weeks[0]); got != tt.firstRow {
+			t.Errorf("calendarWeeksOf(%v, %v): expected first row %v, got %v", tt.date, tt.firstWeekday, tt.firstRow, got)
+		}
+		if got := dayNumbers(weeks[len(weeks)-1]); got != tt.lastRow {
+			t.Errorf("calendarWeeksOf(%v, %v): expected last row %v, got %v", tt.date, tt.firstWeekday, tt.lastRow, got)
+		}
+	}
+}
+
+func dayNumbers(week calendarWeek) [7]int {
+	numbers := [7]int{}
+	for i, day := range week.days {
+		if !day.IsZero() {
+			numbers[i] = day.Day()
+		}
+	}
+	return numbers
+}
+
+func mustLoadLocation(name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return location
+}
+
+func TestWeekdayHeader(t *testing.T) {
+	if got := weekdayHeader(time.Monday, nil); got != "Wk Mo Tu We Th Fr Sa Su" {
+		t.Errorf("expected Monday first, got '%s'", got)
+	}
+	if got := weekdayHeader(time.Sunday, nil); got != "Wk Su Mo Tu We Th Fr Sa" {
+		t.Errorf("expected Sunday first, got '%s'", got)
+	}
+}
+
+func TestWeekdayHeaderLocalized(t *testing.T) {
+	tests := []struct {
+		locale   string
+		expected string
+	}{
+		{"de-DE", "KW Mo Di Mi Do Fr Sa So"},
+		{"fr-FR", " S lu ma me je ve sa di"},
+		{"ja-JP", "週 月 火 水 木 金 土 日"},
+	}
+
+	for _, tt := range tests {
+		l, _ := locale.Lookup(tt.locale)
+		got := weekdayHeader(time.Monday, l)
+		if got != tt.expected {
+			t.Errorf("weekdayHeader(%q): expected '%s', got '%s'", tt.locale, tt.expected, got)
+		}
+		if textWidth(got) != calendarWidth {
+			t.Errorf("weekdayHeader(%q): expected width %d, got %d", tt.locale, calendarWidth, textWidth(got))
+		}
+	}
+}
+
+func TestDrawCalendar(t *testing.T) {
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("failed to initialize screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(23, calendarRows)
+
+	h, _ := holidays.Parse(strings.NewReader("BEGIN:VEVENT\nDTSTART;VALUE=DATE:20231003\nSUMMARY:Unity Day\nEND:VEVENT\n"))
+	drawCalendar(screen, 0, time.Date(2023, 10, 3, 12, 0, 0, 0, time.UTC), time.Monday, h, nil, tcell.StyleDefault)
+
+	expected := []string{
+		"     October 2023      ",
+		"Wk Mo Tu We Th Fr Sa Su",
+		"39                    1",
+		"40  2  3  4  5  6  7  8",
+		"41  9 10 11 12 13 14 15",
+		"42 16 17 18 19 20 21 22",
+		"43 23 24 25 26 27 28 29",
+		"44 30 31               ",
+		"       Unity Day       ",
+	}
+	for y, row := range expected {
+		if got := screenRow(screen, y); got != row {
+			t.Errorf("row %d: expected '%s', got '%s'", y, row, got)
+		}
+	}
+
+	// Today (the 3rd) is highlighted
+	if _, _, style, _ := screen.GetContent(7, 3); style != tcell.StyleDefault.Underline(true).Bold(true).Reverse(true) {
+		t.Errorf("expected today to be highlighted as holiday, got %v", style)
+	}
+	if _, _, style, _ := screen.GetContent(10, 3); style != tcell.StyleDefault {
+		t.Errorf("expected other days not to be highlighted, got %v", style)
+	}
+}
+
+func screenRow(screen tcell.SimulationScreen, y int) string {
+	width, _ := screen.Size()
+	row := []rune{}
+	for x := range width {
+		r, _, _, _ := screen.GetContent(x, y)
+		row = append(row, r)
+	}
+	return string(row)
+}
@@ -0,0 +1,216 @@
+package display
+
+import (
+	"fmt"
+	"time"
+
+	"chrono-ntp/locale"
+)
+
+// Calendar is a calendar system for the date line, instead of the Gregorian
+// calendar of the date format
+type Calendar interface {
+	// Name returns the configuration value of the calendar
+	Name() string
+	// FormatDate returns the date of t (in the time zone of t) in the calendar
+	FormatDate(t time.Time, l *locale.Locale) string
+}
+
+// AllowedCalendars lists the calendars of the date line; gregorian uses the
+// date format
+var AllowedCalendars = [...]string{"gregorian", "hebrew", "islamic", "persian", "chinese", "japanese", "julian", "ethiopian", "republican"}
+
+var calendars = map[string]Calendar{
+	"hebrew":     hebrewCalendar{},
+	"islamic":    islamicCalendar{},
+	"persian":    persianCalendar{},
+	"chinese":    chineseCalendar{},
+	"japanese":   japaneseCalendar{},
+	"julian":     julianCalendar{},
+	"ethiopian":  ethiopianCalendar{},
+	"republican": republicanCalendar{},
+}
+
+// LookupCalendar returns the calendar with the name. The Gregorian calendar
+// is nil, since the date format applies to it.
+func LookupCalendar(name string) (Calendar, bool) {
+	if name == "gregorian" {
+		return nil, true
+	}
+	calendar, ok := calendars[name]
+	return calendar, ok
+}
+
+// fixedDayUnixEpoch is the fixed day number of 1970-01-01
+const fixedDayUnixEpoch = 719163
+
+// fixedDay returns the fixed day number of the date of t in its time zone,
+// which counts days from 0001-01-01 (day 1) in the proleptic Gregorian
+// calendar like Rata Die. The calendars convert from and to fixed days, as in
+// Reingold and Dershowitz, Calendrical Calculations.
+func fixedDay(t time.Time) int {
+	year, month, day := t.Date()
+	return int(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix()/secondsPerDay) + fixedDayUnixEpoch
+}
+
+// timeOfFixedDay returns the start of the fixed day in the location
+func timeOfFixedDay(day int, location *time.Location) time.Time {
+	return time.Date(1970, 1, 1+day-fixedDayUnixEpoch, 0, 0, 0, 0, location)
+}
+
+const secondsPerDay = 24 * 60 * 60
+
+// floorDiv divides rounding towards negative infinity, for dates before the
+// epochs of the calendars
+func floorDiv(a int, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+func floorMod(a int, b int) int {
+	return a - b*floorDiv(a, b)
+}
+
+// julianCalendar is the calendar of Julius Caesar, which is 13 days behind
+// the Gregorian calendar since 1900 and still used by several Orthodox
+// churches
+type julianCalendar struct{}
+
+func (julianCalendar) Name() string {
+	return "julian"
+}
+
+func (julianCalendar) FormatDate(t time.Time, l *locale.Locale) string {
+	year, month, day := julianFromFixed(fixedDay(t))
+	return fmt.Sprintf("%d %s %d (Julian)", day, l.Month(month), year)
+}
+
+// julianFromFixed converts a fixed day to a date of the Julian calendar, with
+// the algorithm of Richards for the Julian Day Number
+// See: https://en.wikipedia.org/wiki/Julian_day#Julian_or_Gregorian_calendar_from_Julian_day_number
+func julianFromFixed(day int) (year int, month time.Month, dayOfMonth int) {
+	f := day + 1721425 + 1401 // Julian Day Number
+	e := 4*f + 3
+	h := 5*floorDiv(floorMod(e, 1461), 4) + 2
+	dayOfMonth = floorDiv(floorMod(h, 153), 5) + 1
+	month = time.Month(floorMod(floorDiv(h, 153)+2, 12) + 1)
+	year = floorDiv(e, 1461) - 4716 + (14-int(month))/12
+	return year, month, dayOfMonth
+}
+
+// ethiopianCalendar has twelve months of 30 days and a thirteenth month of 5
+// or 6 days. The year starts on 11 September (12 September before a
+// Gregorian leap year).
+type ethiopianCalendar struct{}
+
+// ethiopianEpoch is the fixed day of 1 Meskerem 1 (29 August 8 in the Julian
+// calendar)
+const ethiopianEpoch = 2796
+
+var ethiopianMonths = [13]string{
+	"Meskerem", "Tikimt", "Hidar", "Tahsas", "Tir", "Yekatit", "Megabit",
+	"Miyazya", "Ginbot", "Sene", "Hamle", "Nehase", "Pagume",
+}
+
+func (ethiopianCalendar) Name() string {
+	return "ethiopian"
+}
+
+func (ethiopianCalendar) FormatDate(t time.Time, _ *locale.Locale) string {
+	year, month, day := ethiopianFromFixed(fixedDay(t))
+	return fmt.Sprintf("%d %s %d", day, ethiopianMonths[month-1], year)
+}
+
+func fixedFromEthiopian(year int, month int, day int) int {
+	return ethiopianEpoch - 1 + 365*(year-1) + floorDiv(year, 4) + 30*(month-1) + day
+}
+
+func ethiopianFromFixed(day int) (year int, month int, dayOfMonth int) {
+	year = floorDiv(4*(day-ethiopianEpoch)+1463, 1461)
+	month = floorDiv(day-fixedFromEthiopian(year, 1, 1), 30) + 1
+	return year, month, day + 1 - fixedFromEthiopian(year, month, 1)
+}
+
+// islamicCalendar is the tabular (arithmetic) Islamic calendar, with 11 leap
+// years in 30 years. The religious calendar follows the sighting of the
+// crescent moon, which may differ by a day or two.
+type islamicCalendar struct{}
+
+// islamicEpoch is the fixed day of 1 Muharram 1 AH (16 July 622 in the Julian
+// calendar)
+const islamicEpoch = 227015
+
+var islamicMonths = [12]string{
+	"Muharram", "Safar", "Rabi al-Awwal", "Rabi al-Thani", "Jumada al-Awwal", "Jumada al-Thani",
+	"Rajab", "Shaban", "Ramadan", "Shawwal", "Dhu al-Qadah", "Dhu al-Hijjah",
+}
+
+func (islamicCalendar) Name() string {
+	return "islamic"
+}
+
+func (islamicCalendar) FormatDate(t time.Time, _ *locale.Locale) string {
+	year, month, day := islamicFromFixed(fixedDay(t))
+	return fmt.Sprintf("%d %s %d AH", day, islamicMonths[month-1], year)
+}
+
+func fixedFromIslamic(year int, month int, day int) int {
+	return day + 29*(month-1) + floorDiv(6*month-1, 11) + (year-1)*354 + floorDiv(3+11*year, 30) + islamicEpoch - 1
+}
+
+func islamicFromFixed(day int) (year int, month int, dayOfMonth int) {
+	year = floorDiv(30*(day-islamicEpoch)+10646, 10631)
+	month = floorDiv(11*(day-fixedFromIslamic(year, 1, 1))+330, 325)
+	return year, month, day - fixedFromIslamic(year, month, 1) + 1
+}
+
+// japaneseCalendar counts years in the era of the reigning emperor, with the
+// months and days of the Gregorian calendar
+type japaneseCalendar struct{}
+
+type japaneseEra struct {
+	name      string
+	kanji     string
+	firstYear int       // Gregorian year of the first year of the era
+	start     time.Time // First day, in the Gregorian calendar
+}
+
+var japaneseEras = []japaneseEra{
+	{"Reiwa", "令和", 2019, time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)},
+	{"Heisei", "平成", 1989, time.Date(1989, 1, 8, 0, 0, 0, 0, time.UTC)},
+	{"Shōwa", "昭和", 1926, time.Date(1926, 12, 25, 0, 0, 0, 0, time.UTC)},
+	{"Taishō", "大正", 1912, time.Date(1912, 7, 30, 0, 0, 0, 0, time.UTC)},
+	// Japan adopted the Gregorian calendar in Meiji 6 (1873)
+	{"Meiji", "明治", 1868, time.Date(1873, 1, 1, 0, 0, 0, 0, time.UTC)},
+}
+
+func (japaneseCalendar) Name() string {
+	return "japanese"
+}
+
+// FormatDate returns the era, year, month and day (e.g. "Reiwa 8, October
+// 19"), or in Japanese for the ja-JP locale (e.g. "令和8年10月19日", with 元年
+// for the first year of an era). Dates before Japan adopted the Gregorian
+// calendar are shown as ISO 8601 dates.
+func (japaneseCalendar) FormatDate(t time.Time, l *locale.Locale) string {
+	year, month, day := t.Date()
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	for _, era := range japaneseEras {
+		if date.Before(era.start) {
+			continue
+		}
+		eraYear := year - era.firstYear + 1
+		if l != nil && l.Name == "ja-JP" {
+			yearText := fmt.Sprintf("%d", eraYear)
+			if eraYear == 1 {
+				yearText = "元"
+			}
+			return fmt.Sprintf("%s%s年%d月%d日", era.kanji, yearText, month, day)
+		}
+		return fmt.Sprintf("%s %d, %s %d", era.name, eraYear, l.Month(month), day)
+	}
+	return t.Format("2006-01-02")
+}
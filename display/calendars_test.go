@@ -0,0 +1,153 @@
+package display
+
+import (
+	"testing"
+	"time"
+
+	"chrono-ntp/locale"
+)
+
+// Reference dates include the sample dates of Reingold and Dershowitz,
+// Calendrical Calculations (appendix C), such as 1945-11-12
+func TestCalendars(t *testing.T) {
+	tests := []struct {
+		calendar string
+		date     time.Time
+		expected string
+	}{
+		{"julian", date(1945, 11, 12), "30 October 1945 (Julian)"},
+		{"julian", date(2000, 1, 1), "19 December 1999 (Julian)"},
+		// The first day of the Gregorian calendar
+		{"julian", date(1582, 10, 15), "5 October 1582 (Julian)"},
+		{"julian", date(2026, 10, 19), "6 October 2026 (Julian)"},
+
+		{"ethiopian", date(1945, 11, 12), "3 Hidar 1938"},
+		{"ethiopian", date(2026, 9, 10), "5 Pagume 2018"},
+		{"ethiopian", date(2026, 9, 11), "1 Meskerem 2019"},
+		// New year before a Gregorian leap year
+		{"ethiopian", date(2023, 9, 12), "1 Meskerem 2016"},
+
+		{"islamic", date(1945, 11, 12), "6 Dhu al-Hijjah 1364 AH"},
+		{"islamic", date(622, 7, 19), "1 Muharram 1 AH"},
+		{"islamic", date(2024, 3, 10), "29 Shaban 1445 AH"},
+		{"islamic", date(2026, 2, 18), "1 Ramadan 1447 AH"},
+
+		{"hebrew", date(1945, 11, 12), "7 Kislev 5706"},
+		// Rosh Hashanah and Passover
+		{"hebrew", date(2026, 9, 12), "1 Tishrei 5787"},
+		{"hebrew", date(2026, 9, 11), "29 Elul 5786"},
+		{"hebrew", date(2026, 4, 2), "15 Nisan 5786"},
+		// 5784 is a leap year
+		{"hebrew", date(2024, 2, 20), "11 Adar I 5784"},
+		{"hebrew", date(2024, 3, 11), "1 Adar II 5784"},
+		{"hebrew", date(2026, 10, 19), "8 Cheshvan 5787"},
+
+		{"persian", date(1945, 11, 12), "21 Aban 1324 SH"},
+		// Nowruz, after the leap year 1403
+		{"persian", date(2025, 3, 20), "30 Esfand 1403 SH"},
+		{"persian", date(2025, 3, 21), "1 Farvardin 1404 SH"},
+		{"persian", date(2026, 3, 20), "29 Esfand 1404 SH"},
+		{"persian", date(2026, 3, 21), "1 Farvardin 1405 SH"},
+		{"persian", date(2026, 10, 19), "27 Mehr 1405 SH"},
+
+		{"japanese", date(1873, 1, 1), "Meiji 6, January 1"},
+		{"japanese", date(1872, 12, 31), "1872-12-31"},
+		{"japanese", date(1989, 1, 7), "Shōwa 64, January 7"},
+		{"japanese", date(1989, 1, 8), "Heisei 1, January 8"},
+		{"japanese", date(2019, 4, 30), "Heisei 31, April 30"},
+		{"japanese", date(2019, 5, 1), "Reiwa 1, May 1"},
+		{"japanese", date(2026, 10, 19), "Reiwa 8, October 19"},
+
+		{"republican", date(1792, 9, 22), "1 Vendémiaire An I"},
+		{"republican", date(1792, 9, 21), "1792-09-21"},
+		{"republican", date(1794, 7, 27), "9 Thermidor An II"},
+		// An III is a leap year, the equinox of 1795 was on 23 September
+		{"republican", date(1795, 9, 22), "Jour de la Révolution An III"},
+		{"republican", date(1795, 9, 23), "1 Vendémiaire An IV"},
+		{"republican", date(1799, 11, 9), "18 Brumaire An VIII"},
+		{"republican", date(1804, 12, 2), "11 Frimaire An XIII"},
+		{"republican", date(2026, 10, 19), "27 Vendémiaire An CCXXXV"},
+	}
+
+	for _, tt := range tests {
+		calendar, ok := LookupCalendar(tt.calendar)
+		if !ok {
+			t.Fatalf("LookupCalendar(%q): not found", tt.calendar)
+		}
+		if got := calendar.FormatDate(tt.date, nil); got != tt.expected {
+			t.Errorf("%s FormatDate(%s): expected '%s', got '%s'", tt.calendar, tt.date.Format(time.DateOnly), tt.expected, got)
+		}
+	}
+}
+
+func TestCalendars_TimeZone(t *testing.T) {
+	// Still 29 Elul in New York when it is 1 Tishrei in UTC
+	newYork := mustLoadLocation("America/New_York")
+	calendar, _ := LookupCalendar("hebrew")
+	if got := calendar.FormatDate(time.Date(2026, 9, 12, 2, 0, 0, 0, time.UTC).In(newYork), nil); got != "29 Elul 5786" {
+		t.Errorf("expected '29 Elul 5786', got '%s'", got)
+	}
+}
+
+func TestJapaneseCalendar_Locale(t *testing.T) {
+	ja, _ := locale.Lookup("ja-JP")
+	tests := []struct {
+		date     time.Time
+		expected string
+	}{
+		{date(2019, 5, 1), "令和元年5月1日"},
+		{date(2026, 10, 19), "令和8年10月19日"},
+		{date(1989, 1, 7), "昭和64年1月7日"},
+	}
+
+	for _, tt := range tests {
+		if got := (japaneseCalendar{}).FormatDate(tt.date, ja); got != tt.expected {
+			t.Errorf("FormatDate(%s, ja-JP): expected '%s', got '%s'", tt.date.Format(time.DateOnly), tt.expected, got)
+		}
+	}
+}
+
+func TestLookupCalendar(t *testing.T) {
+	for _, name := range AllowedCalendars {
+		calendar, ok := LookupCalendar(name)
+		if !ok {
+			t.Errorf("LookupCalendar(%q): not found", name)
+			continue
+		}
+		if name == "gregorian" {
+			if calendar != nil {
+				t.Errorf("LookupCalendar(gregorian): expected nil, got %v", calendar)
+			}
+		} else if calendar.Name() != name {
+			t.Errorf("LookupCalendar(%q): got calendar %q", name, calendar.Name())
+		}
+	}
+	if _, ok := LookupCalendar("mayan"); ok {
+		t.Errorf("LookupCalendar(mayan): expected not found")
+	}
+}
+
+func TestFixedDay(t *testing.T) {
+	tests := []struct {
+		date     time.Time
+		expected int
+	}{
+		{date(1, 1, 1), 1},
+		{date(1970, 1, 1), 719163},
+		{date(1945, 11, 12), 710347},
+		{time.Date(2000, 1, 1, 23, 59, 59, 0, mustLoadLocation("Pacific/Kiritimati")), 730120},
+	}
+
+	for _, tt := range tests {
+		if got := fixedDay(tt.date); got != tt.expected {
+			t.Errorf("fixedDay(%v): expected %d, got %d", tt.date, tt.expected, got)
+		}
+		if got := timeOfFixedDay(tt.expected, time.UTC); got.Format(time.DateOnly) != tt.date.Format(time.DateOnly) {
+			t.Errorf("timeOfFixedDay(%d): expected %s, got %s", tt.expected, tt.date.Format(time.DateOnly), got.Format(time.DateOnly))
+		}
+	}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 12, 0, 0, 0, time.UTC)
+}
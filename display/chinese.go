@@ -0,0 +1,118 @@
+package display
+
+import (
+	"fmt"
+	"time"
+
+	"chrono-ntp/astronomy"
+	"chrono-ntp/locale"
+)
+
+// chineseCalendar is the lunisolar Chinese calendar with the astronomical
+// rules in use since 1645: months start on the day of the new moon in Beijing
+// time, the winter solstice is in month 11, and a year with 13 months repeats
+// the first month without a major solar term (zhongqi) as a leap month.
+// See: https://en.wikipedia.org/wiki/Chinese_calendar
+type chineseCalendar struct{}
+
+// chinaZone is the standard time of China, at 120°E
+var chinaZone = time.FixedZone("CST", 8*60*60)
+
+var (
+	heavenlyStems   = [10]string{"Jia", "Yi", "Bing", "Ding", "Wu", "Ji", "Geng", "Xin", "Ren", "Gui"}
+	earthlyBranches = [12]string{"Zi", "Chou", "Yin", "Mao", "Chen", "Si", "Wu", "Wei", "Shen", "You", "Xu", "Hai"}
+	zodiacAnimals   = [12]string{"Rat", "Ox", "Tiger", "Rabbit", "Dragon", "Snake", "Horse", "Goat", "Monkey", "Rooster", "Dog", "Pig"}
+)
+
+// chineseDate is a date of the Chinese calendar. The year is the Gregorian
+// year in which the Chinese year starts.
+type chineseDate struct {
+	year  int
+	month int
+	leap  bool
+	day   int
+}
+
+func (chineseCalendar) Name() string {
+	return "chinese"
+}
+
+// FormatDate returns the month and day with the sexagenary name and zodiac
+// animal of the year (e.g. "Month 9 Day 10, Bing-Wu (Horse)")
+func (chineseCalendar) FormatDate(t time.Time, _ *locale.Locale) string {
+	date := chineseFromFixed(fixedDay(t))
+	month := fmt.Sprintf("Month %d", date.month)
+	if date.leap {
+		month = "Leap " + month
+	}
+	// 4 AD was a Jia-Zi (Rat) year, the first of the sexagenary cycle
+	cycle := floorMod(date.year-4, 60)
+	return fmt.Sprintf("%s Day %d, %s-%s (%s)", month, date.day, heavenlyStems[cycle%10], earthlyBranches[cycle%12], zodiacAnimals[cycle%12])
+}
+
+// chineseNewMoonDay returns the fixed day (in China) of the new moon of the
+// lunation
+func chineseNewMoonDay(lunation int) int {
+	return fixedDay(astronomy.NewMoon(lunation).In(chinaZone))
+}
+
+// chineseLunationOnOrBefore returns the lunation whose month starts on or
+// before the fixed day in China
+func chineseLunationOnOrBefore(day int) int {
+	lunation := astronomy.LunationAt(timeOfFixedDay(day+1, chinaZone))
+	for chineseNewMoonDay(lunation) > day {
+		lunation--
+	}
+	return lunation
+}
+
+// chineseMajorSolarTerm returns the number of the last major solar term
+// (every 30° of solar longitude) at the start of the fixed day in China
+func chineseMajorSolarTerm(day int) int {
+	return int(astronomy.SolarLongitude(timeOfFixedDay(day, chinaZone)) / 30)
+}
+
+// chineseWinterSolstice returns the fixed day (in China) of the December
+// solstice of the Gregorian year, when the solar longitude is 270°
+func chineseWinterSolstice(year int) int {
+	solstice := astronomy.SolarLongitudeTime(270, time.Date(year, 12, 21, 0, 0, 0, 0, time.UTC))
+	return fixedDay(solstice.In(chinaZone))
+}
+
+// chineseMonth11 returns the lunation of month 11 of the Gregorian year,
+// which contains the winter solstice
+func chineseMonth11(year int) int {
+	return chineseLunationOnOrBefore(chineseWinterSolstice(year))
+}
+
+func chineseFromFixed(day int) chineseDate {
+	year := timeOfFixedDay(day, time.UTC).Year()
+	// The months from one month 11 to the next
+	start, end := chineseMonth11(year), chineseMonth11(year+1)
+	if chineseNewMoonDay(start) > day {
+		start, end = chineseMonth11(year-1), start
+	}
+	leapYear := end-start == 13
+
+	lunation := chineseLunationOnOrBefore(day)
+	date := chineseDate{month: 11}
+	leapFound := false
+	for l := start + 1; l <= lunation; l++ {
+		monthStart, nextMonthStart := chineseNewMoonDay(l), chineseNewMoonDay(l+1)
+		if leapYear && !leapFound && chineseMajorSolarTerm(monthStart) == chineseMajorSolarTerm(nextMonthStart) {
+			leapFound = true
+			date.leap = true
+		} else {
+			date.month = date.month%12 + 1
+			date.leap = false
+		}
+	}
+	date.day = day - chineseNewMoonDay(lunation) + 1
+
+	// Months 11 and 12 in January and February belong to the previous year
+	date.year = year
+	if date.month >= 11 && timeOfFixedDay(day, time.UTC).Month() < time.June {
+		date.year--
+	}
+	return date
+}
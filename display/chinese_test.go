@@ -0,0 +1,41 @@
+package display
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChineseCalendar(t *testing.T) {
+	tests := []struct {
+		date     time.Time
+		expected string
+	}{
+		// New years
+		{date(1985, 2, 20), "Month 1 Day 1, Yi-Chou (Ox)"},
+		{date(2023, 1, 22), "Month 1 Day 1, Gui-Mao (Rabbit)"},
+		{date(2024, 2, 10), "Month 1 Day 1, Jia-Chen (Dragon)"},
+		{date(2025, 1, 29), "Month 1 Day 1, Yi-Si (Snake)"},
+		{date(2026, 2, 16), "Month 12 Day 29, Yi-Si (Snake)"},
+		{date(2026, 2, 17), "Month 1 Day 1, Bing-Wu (Horse)"},
+		// Leap months
+		{date(2012, 5, 21), "Leap Month 4 Day 1, Ren-Chen (Dragon)"},
+		{date(2020, 5, 23), "Leap Month 4 Day 1, Geng-Zi (Rat)"},
+		{date(2023, 3, 22), "Leap Month 2 Day 1, Gui-Mao (Rabbit)"},
+		{date(2023, 4, 20), "Month 3 Day 1, Gui-Mao (Rabbit)"},
+		{date(2025, 7, 25), "Leap Month 6 Day 1, Yi-Si (Snake)"},
+		{date(2025, 8, 23), "Month 7 Day 1, Yi-Si (Snake)"},
+		// Leap month 11 of 2033 (the "2033 problem")
+		{date(2033, 12, 22), "Leap Month 11 Day 1, Gui-Chou (Ox)"},
+		{date(2034, 2, 19), "Month 1 Day 1, Jia-Yin (Tiger)"},
+		// Mid-Autumn Festival
+		{date(2025, 10, 6), "Month 8 Day 15, Yi-Si (Snake)"},
+		{date(2026, 9, 25), "Month 8 Day 15, Bing-Wu (Horse)"},
+		{date(2026, 10, 19), "Month 9 Day 10, Bing-Wu (Horse)"},
+	}
+
+	for _, tt := range tests {
+		if got := (chineseCalendar{}).FormatDate(tt.date, nil); got != tt.expected {
+			t.Errorf("FormatDate(%s): expected '%s', got '%s'", tt.date.Format(time.DateOnly), tt.expected, got)
+		}
+	}
+}
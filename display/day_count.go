@@ -0,0 +1,65 @@
+package display
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"chrono-ntp/astronomy"
+)
+
+// MaxDayCountPrecision is the maximum number of decimals of the day counts; a
+// Julian Date with more digits exceeds the precision of a float64
+const MaxDayCountPrecision = 8
+
+const (
+	// Julian Dates of the epochs of the day counts
+	modifiedJulianDateEpoch = 2400000.5 // 1858-11-17 00:00 UTC
+	rataDieEpoch            = 1721424.5 // 0000-12-31, so 0001-01-01 is day 1
+	excelSerialEpoch        = 2415018.5 // 1899-12-30, which accounts for Lotus 1-2-3 treating 1900 as a leap year
+)
+
+var dayCountFormats = []string{"jd", "mjd", "rata-die", "excel-serial", "mars-sol-date"}
+
+// localJulianDate returns the Julian Date of the wall clock time of t, for day
+// counts that follow the local calendar
+func localJulianDate(t time.Time) float64 {
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	return astronomy.JulianDate(time.Date(year, month, day, hour, min, sec, t.Nanosecond(), time.UTC))
+}
+
+// formatDayCount returns the day count of the format with the given number of
+// decimals. The Julian Date, Modified Julian Date and Mars Sol Date count in
+// UTC; Rata Die and Excel serial dates count in the time zone of t.
+func formatDayCount(t time.Time, timeFormat string, decimals int) string {
+	var days float64
+	switch timeFormat {
+	case "mjd":
+		days = astronomy.JulianDate(t) - modifiedJulianDateEpoch
+	case "rata-die":
+		days = localJulianDate(t) - rataDieEpoch
+	case "excel-serial":
+		days = localJulianDate(t) - excelSerialEpoch
+	case "mars-sol-date":
+		days = marsSolDate(t)
+	default:
+		days = astronomy.JulianDate(t)
+	}
+	return formatDecimals(days, decimals)
+}
+
+// formatDecimals formats a value truncated (not rounded) to the number of
+// decimals, so a clock never shows a value before it is reached
+func formatDecimals(value float64, decimals int) string {
+	decimals = max(min(decimals, MaxDayCountPrecision), 0)
+	scale := math.Pow10(decimals)
+	return strconv.FormatFloat(math.Floor(value*scale)/scale, 'f', decimals, 64)
+}
+
+// dayCountRedrawPrecision returns the number of fractional second digits
+// needed to show every change of a day count with the given decimals (e.g.
+// tenths of a second for 0.00001 days = 0.864 seconds)
+func dayCountRedrawPrecision(decimals int) int {
+	return max(min(decimals-4, MaxTimePrecision), 0)
+}
@@ -0,0 +1,78 @@
+package display
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatDayCount(t *testing.T) {
+	j2000 := time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)
+	// 1999-12-31 23:30 UTC, but already 2000-01-01 in the time zone
+	newYearCET := time.Date(2000, 1, 1, 0, 30, 0, 0, time.FixedZone("CET", 3600))
+	tests := []struct {
+		format   string
+		t        time.Time
+		decimals int
+		expected string
+	}{
+		{"jd", j2000, 5, "2451545.00000"},
+		{"jd", j2000, 0, "2451545"},
+		// Truncated, not rounded: 0.99998843 days
+		{"jd", j2000.Add(-time.Second), 5, "2451544.99998"},
+		{"jd", time.Date(1858, 11, 17, 0, 0, 0, 0, time.UTC), 1, "2400000.5"},
+		{"jd", newYearCET, 5, "2451544.47916"},
+		{"mjd", j2000, 5, "51544.50000"},
+		{"mjd", time.Date(1858, 11, 17, 0, 0, 0, 0, time.UTC), 3, "0.000"},
+		{"mjd", time.Date(2024, 2, 29, 18, 0, 0, 0, time.UTC), 2, "60369.75"},
+		{"rata-die", time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC), 0, "1"},
+		{"rata-die", j2000, 5, "730120.50000"},
+		{"rata-die", newYearCET, 5, "730120.02083"},
+		{"excel-serial", time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC), 0, "0"},
+		{"excel-serial", j2000, 5, "36526.50000"},
+		{"excel-serial", newYearCET, 2, "36526.02"},
+		// Mars24 example A
+		{"mars-sol-date", time.Date(2000, 1, 6, 0, 0, 0, 0, time.UTC), 5, "44795.99976"},
+		// Decimals are limited to the precision of a float64
+		{"jd", j2000, 12, "2451545.00000000"},
+	}
+
+	for _, tt := range tests {
+		if got := formatDayCount(tt.t, tt.format, tt.decimals); got != tt.expected {
+			t.Errorf("formatDayCount(%v, %q, %d): expected '%s', got '%s'", tt.t, tt.format, tt.decimals, tt.expected, got)
+		}
+	}
+}
+
+func TestFormatTimeWithOptions_DayCount(t *testing.T) {
+	format := "mjd"
+	j2000 := time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)
+	if got := FormatTimeWithOptions(j2000, &format, FormatOptions{DayCountPrecision: 3}); got != "51544.500" {
+		t.Errorf("expected '51544.500', got '%s'", got)
+	}
+}
+
+func TestRedrawPrecision(t *testing.T) {
+	tests := []struct {
+		format            string
+		precision         int
+		dayCountPrecision int
+		expected          int
+	}{
+		{"ISO8601", 2, 5, 2},
+		{"custom:%H:%M:%S.%3N", 0, 5, 3},
+		{"jd", 3, 0, 0},
+		{"jd", 0, 4, 0},
+		{"mjd", 0, 5, 1},
+		{"rata-die", 0, 6, 2},
+		{"mars-sol-date", 0, 8, 3},
+		{"unix-ms", 0, 5, 3},
+		{"ntp", 0, 5, 3},
+		{"cocoa", 1, 5, 1},
+	}
+
+	for _, tt := range tests {
+		if got := RedrawPrecision(tt.format, tt.precision, tt.dayCountPrecision); got != tt.expected {
+			t.Errorf("RedrawPrecision(%q, %d, %d): expected %d, got %d", tt.format, tt.precision, tt.dayCountPrecision, tt.expected, got)
+		}
+	}
+}
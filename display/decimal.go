@@ -0,0 +1,59 @@
+package display
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// decimalSecond is a second of French decimal time, 1/100000 of a day
+const decimalSecond = 864 * time.Millisecond
+
+// decimalRedrawIntervals maps a time precision to the interval between
+// redraws for decimal time, like redrawIntervals. Hundredths of a decimal
+// second would need redraws every 8.64 ms, so every other one is shown.
+var decimalRedrawIntervals = [...]time.Duration{
+	decimalSecond,
+	decimalSecond / 10,
+	decimalSecond / 50,
+	decimalSecond / 50,
+}
+
+// sinceMidnight returns the wall clock time of day of t
+func sinceMidnight(t time.Time) time.Duration {
+	hour, min, sec := t.Clock()
+	return time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute + time.Duration(sec)*time.Second + time.Duration(t.Nanosecond())
+}
+
+// formatDecimalTime returns French Revolutionary decimal time, with 10 hours
+// of 100 minutes of 100 seconds a day, and the given number of fractional
+// decimal second digits (e.g. "5:83:33.3" for 14:00 and one digit)
+// See: https://en.wikipedia.org/wiki/Decimal_time#France
+func formatDecimalTime(t time.Time, precision int) string {
+	precision = max(min(precision, MaxTimePrecision), 0)
+	scale := int64(math.Pow10(precision))
+	// Truncated like the seconds of the other formats
+	units := int64(sinceMidnight(t)) * scale / int64(decimalSecond)
+	seconds := units / scale
+	formatted := fmt.Sprintf("%d:%02d:%02d", seconds/10000, seconds/100%100, seconds%100)
+	if precision > 0 {
+		formatted += fmt.Sprintf(".%0*d", precision, units%scale)
+	}
+	return formatted
+}
+
+// isDecimalTickEdge reports whether t is just after a decimal second boundary
+func isDecimalTickEdge(t time.Time) bool {
+	return sinceMidnight(t)%decimalSecond < decimalSecond/8
+}
+
+// decimalRedrawInterval returns the interval between redraws for decimal time
+// with the given precision. The interval does not divide an hour, so the
+// redraws must be aligned to local midnight.
+func decimalRedrawInterval(precision int, tickIndicator bool) time.Duration {
+	interval := decimalRedrawIntervals[max(min(precision, MaxTimePrecision), 0)]
+	if tickIndicator {
+		interval = min(interval, decimalSecond/8)
+	}
+	return interval
+}
@@ -0,0 +1,82 @@
+package display
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatDecimalTime(t *testing.T) {
+	paris := mustLoadLocation("Europe/Paris")
+	tests := []struct {
+		t         time.Time
+		precision int
+		expected  string
+	}{
+		{time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), 0, "0:00:00"},
+		{time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC), 0, "5:00:00"},
+		{time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC), 0, "5:83:33"},
+		{time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC), 1, "5:83:33.3"},
+		{time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC), 3, "5:83:33.333"},
+		{time.Date(2026, 10, 19, 23, 59, 59, 999_999_999, time.UTC), 0, "9:99:99"},
+		// Truncated: 863 ms is not yet a decimal second
+		{time.Date(2026, 10, 19, 0, 0, 0, 863_000_000, time.UTC), 0, "0:00:00"},
+		{time.Date(2026, 10, 19, 0, 0, 0, 864_000_000, time.UTC), 0, "0:00:01"},
+		// The wall clock of the time zone
+		{time.Date(2026, 10, 19, 6, 0, 0, 0, paris), 0, "2:50:00"},
+		{time.Date(2026, 10, 19, 6, 0, 0, 0, paris), -1, "2:50:00"},
+	}
+
+	for _, tt := range tests {
+		if got := formatDecimalTime(tt.t, tt.precision); got != tt.expected {
+			t.Errorf("formatDecimalTime(%v, %d): expected '%s', got '%s'", tt.t, tt.precision, tt.expected, got)
+		}
+	}
+}
+
+func TestRedrawIntervalFor(t *testing.T) {
+	tests := []struct {
+		format        string
+		precision     int
+		tickIndicator bool
+		expected      time.Duration
+	}{
+		{"ISO8601", 0, false, time.Second},
+		{"ISO8601", 2, false, 10 * time.Millisecond},
+		{"jd", 0, false, 100 * time.Millisecond},
+		{"decimal", 0, false, 864 * time.Millisecond},
+		{"decimal", 0, true, 108 * time.Millisecond},
+		{"decimal", 1, false, 86400 * time.Microsecond},
+		{"decimal", 3, false, 17280 * time.Microsecond},
+		// Sidereal seconds are shorter than SI seconds
+		{"gmst", 0, false, 997269566 * time.Nanosecond},
+		{"lst", 1, false, 99726956 * time.Nanosecond},
+		{"gast", 3, false, 9972695 * time.Nanosecond},
+		{"gmst", 0, true, 125 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		if got := RedrawIntervalFor(tt.format, tt.precision, 5, tt.tickIndicator); got != tt.expected {
+			t.Errorf("RedrawIntervalFor(%q, %d, 5, %v): expected %v, got %v", tt.format, tt.precision, tt.tickIndicator, tt.expected, got)
+		}
+	}
+}
+
+func TestIsDecimalTickEdge(t *testing.T) {
+	tests := []struct {
+		t        time.Time
+		expected bool
+	}{
+		{time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), true},
+		{time.Date(2026, 10, 19, 0, 0, 0, 107_999_999, time.UTC), true},
+		{time.Date(2026, 10, 19, 0, 0, 0, 108_000_000, time.UTC), false},
+		{time.Date(2026, 10, 19, 0, 0, 0, 864_000_000, time.UTC), true},
+		// A whole second is not a decimal second boundary
+		{time.Date(2026, 10, 19, 0, 0, 1, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		if got := isDecimalTickEdge(tt.t); got != tt.expected {
+			t.Errorf("isDecimalTickEdge(%v): expected %v, got %v", tt.t, tt.expected, got)
+		}
+	}
+}
@@ -1,51 +1,118 @@
 package display
 
 import (
-	"slices"
 	"strings"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
+
+	"chrono-ntp/holidays"
+	"chrono-ntp/leapseconds"
+	"chrono-ntp/locale"
 )
 
 type DisplayState struct {
-	Now           time.Time
-	DateFormat    string
-	TimeFormat    string
-	HideDate      bool
-	ShowTimeZone  bool
-	HideStatusBar bool
-	TimeZone      *time.Location
-	Offset        time.Duration
-	Offline       bool
+	Now               time.Time
+	DateFormat        string
+	Calendar          Calendar // Nil is the Gregorian calendar
+	Mode              string
+	TimeFormat        string
+	TimePrecision     int
+	TickIndicator     bool
+	HideDate          bool
+	ShowTimeZone      bool
+	HideStatusBar     bool
+	TimeZone          *time.Location
+	Offset            time.Duration
+	Offline           bool
+	LastSync          time.Time
+	HealthBorder      bool
+	ShowGraph         bool
+	ShowCalendar      bool
+	FirstWeekday      time.Weekday
+	Holidays          *holidays.Holidays
+	Locale            *locale.Locale     // Nil is US English
+	LeapSeconds       *leapseconds.Table // Nil is the embedded table
+	ShowAstronomy     bool
+	Latitude          float64
+	Longitude         float64
+	MarsLocation      *MarsLocation // Nil is the prime meridian
+	DayCountPrecision int
+	HistoryTimes      []time.Time // Server times of the history samples
+	OffsetHistory     []time.Duration
+	RTTHistory        []time.Duration
+	ShowHelp          bool
+}
+
+// timeFormatLabels are shown instead of the time zone for time formats that
+// do not show the time of the time zone
+var timeFormatLabels = map[string]string{
+	"lunar":         "Coordinated Lunar Time",
+	"tai":           "International Atomic Time",
+	"gps":           "GPS Time",
+	"loran":         "LORAN-C Time",
+	"tt":            "Terrestrial Time",
+	"jd":            "Julian Date",
+	"mjd":           "Modified Julian Date",
+	"mars-sol-date": "Mars Sol Date",
+}
+
+// formatDateLine returns the date in the calendar, or in the date format for
+// the Gregorian calendar
+func formatDateLine(state DisplayState) string {
+	if state.Calendar != nil {
+		return state.Calendar.FormatDate(state.Now, state.Locale)
+	}
+	return FormatDateLocalized(state.Now, &state.DateFormat, state.Locale)
+}
+
+type textLine struct {
+	text  string
+	style tcell.Style
 }
 
 type Display struct {
-	screen tcell.Screen
+	screen      tcell.Screen
+	keyBindings KeyBindings
+	theme       Theme
 }
 
-func NewDisplay() (*Display, error) {
+func NewDisplay(keyBindings KeyBindings, theme Theme) (*Display, error) {
 	screen, err := tcell.NewScreen()
-	return &Display{screen: screen}, err
+	return &Display{screen: screen, keyBindings: keyBindings, theme: theme}, err
 }
 
 func (d *Display) Init() error {
-	return d.screen.Init()
+	if err := d.screen.Init(); err != nil {
+		return err
+	}
+	d.theme = d.theme.ForColors(d.screen.Colors())
+	d.screen.SetStyle(d.theme.style(ElementBackground))
+	return nil
 }
 
 func (d *Display) Finalize() {
 	d.screen.Fini()
 }
 
-func (d *Display) PollEvents(quitChan chan<- struct{}) {
+func (d *Display) PollEvents(actionChan chan<- Action) {
 	for {
 		ev := d.screen.PollEvent()
 		switch tev := ev.(type) {
 		case *tcell.EventKey:
-			if tev.Key() == tcell.KeyCtrlC || slices.Contains([]rune{'q', 'Q'}, tev.Rune()) {
-				quitChan <- struct{}{}
+			if tev.Key() == tcell.KeyCtrlC {
+				actionChan <- ActionQuit
 				return
 			}
+			if tev.Key() != tcell.KeyRune {
+				continue
+			}
+			if action, ok := d.keyBindings[tev.Rune()]; ok {
+				actionChan <- action
+				if action == ActionQuit {
+					return
+				}
+			}
 		case *tcell.EventResize:
 			d.screen.Sync()
 		}
@@ -57,40 +124,141 @@ func (d *Display) SetInitText(text string) {
 	centerY := height/2 - 1
 
 	d.screen.Clear()
-	drawTextCentered(d.screen, centerY, text, tcell.StyleDefault.Bold(true))
+	drawTextCentered(d.screen, centerY, text, d.theme.style(ElementTime))
 	d.screen.Show()
 }
 
 func (d *Display) Update(state DisplayState) {
 	d.screen.Clear()
 
+	// Panels are stacked above the status bar, bottom is the first row used by
+	// them
 	_, height := d.screen.Size()
-	centerY := height/2 - 1
+	bottom := height
+	if state.HealthBorder {
+		bottom--
+	}
+	if !state.HideStatusBar {
+		bottom--
+	}
+	panelsShown := false
+	if state.ShowGraph {
+		bottom -= graphRows + 1
+		drawGraph(d.screen, bottom, state.HistoryTimes, state.OffsetHistory, state.RTTHistory, d.theme.style(ElementGraph))
+		panelsShown = true
+	}
+	if state.ShowAstronomy {
+		bottom -= astronomyRows + 1
+		drawAstronomy(d.screen, bottom, state.Now, state.Latitude, state.Longitude, d.theme.style(ElementAstronomy))
+		panelsShown = true
+	}
+	if state.ShowCalendar {
+		bottom -= calendarRows + 1
+		drawCalendar(d.screen, bottom, state.Now, state.FirstWeekday, state.Holidays, state.Locale, d.theme.style(ElementCalendar))
+		panelsShown = true
+	}
 
-	drawTextCentered(d.screen, centerY, FormatTime(state.Now, &state.TimeFormat), tcell.StyleDefault.Bold(true))
+	switch state.Mode {
+	case "analog":
+		d.drawAnalog(state, bottom)
+	default:
+		// Without panels, the time stays centered on the whole screen
+		if !panelsShown {
+			bottom = height
+		}
+		d.drawDigital(state, bottom)
+	}
+
+	if state.HealthBorder {
+		drawHealthBorder(d.screen, d.theme.style(ElementBackground), ClassifyHealth(state))
+	}
+
+	if !state.HideStatusBar {
+		drawStatusBar(d.screen, state, d.keyBindings, d.theme)
+	}
+
+	if state.ShowHelp {
+		drawHelp(d.screen, d.keyBindings, d.theme)
+	}
+
+	d.screen.Show()
+}
+
+// drawDigital draws the time, date and time zone centered above bottom
+func (d *Display) drawDigital(state DisplayState, bottom int) {
+	centerY := bottom/2 - 1
+
+	timeStyle := d.theme.style(ElementTime)
+	tickEdge := IsTickEdge(state.Now)
+	if state.TimeFormat == "decimal" {
+		tickEdge = isDecimalTickEdge(state.Now)
+	}
+	if state.TickIndicator && tickEdge {
+		timeStyle = timeStyle.Reverse(true)
+	}
+	// Multi-row time formats grow downwards, the date stays above the first row
+	timeRows := strings.Split(FormatTimeWithOptions(state.Now, &state.TimeFormat, FormatOptions{
+		Precision:         state.TimePrecision,
+		Locale:            state.Locale,
+		LeapSeconds:       state.LeapSeconds,
+		Longitude:         state.Longitude,
+		MarsLocation:      state.MarsLocation,
+		DayCountPrecision: state.DayCountPrecision,
+	}), "\n")
+	for i, row := range timeRows {
+		drawTextCentered(d.screen, centerY+i, row, timeStyle)
+	}
 
 	if !state.HideDate {
-		drawTextCentered(d.screen, centerY-1, FormatDate(state.Now, &state.DateFormat), tcell.StyleDefault)
+		date := formatDateLine(state)
+		if state.TimeFormat == "mars" {
+			date = formatDarianDate(state.Now, state.MarsLocation)
+		}
+		drawTextCentered(d.screen, centerY-1, date, d.theme.style(ElementDate))
 	}
 
 	if state.ShowTimeZone {
-		var timeZoneLabel string
-		switch state.TimeFormat {
-		case "mars":
-			timeZoneLabel = "Coordinated Mars Time"
-		case "lunar":
-			timeZoneLabel = "Coordinated Lunar Time"
-		default:
-			timeZoneLabel = normalizeTimeZoneName(state.TimeZone)
+		label, ok := timeFormatLabels[state.TimeFormat]
+		if !ok {
+			switch state.TimeFormat {
+			case "dtg":
+				label = militaryTimeZoneLabel(state.Now)
+			case "mars":
+				label = marsTimeZoneLabel(state.MarsLocation)
+			case "gmst", "gast", "lst":
+				label = siderealTimeZoneLabel(state.TimeFormat, state.Longitude)
+			default:
+				label = timeZoneLabel(state.Now.In(state.TimeZone))
+			}
 		}
-		drawTextCentered(d.screen, centerY+1, timeZoneLabel, tcell.StyleDefault)
+		drawTextCentered(d.screen, centerY+len(timeRows), label, d.theme.style(ElementTimeZone))
 	}
+}
 
-	if !state.HideStatusBar {
-		drawStatusBar(d.screen, state)
+// drawAnalog draws a clock face filling the screen above bottom, with the date
+// and time zone below it. The time format is ignored, the face always shows the
+// (Earth) time in the configured time zone.
+func (d *Display) drawAnalog(state DisplayState, bottom int) {
+	top := 1
+
+	labels := []textLine{}
+	if !state.HideDate {
+		labels = append(labels, textLine{formatDateLine(state), d.theme.style(ElementDate)})
 	}
+	if state.ShowTimeZone {
+		labels = append(labels, textLine{timeZoneLabel(state.Now.In(state.TimeZone)), d.theme.style(ElementTimeZone)})
+	}
+	bottom -= len(labels)
 
-	d.screen.Show()
+	faceStyle := d.theme.style(ElementTime)
+	if state.TickIndicator && IsTickEdge(state.Now) {
+		faceStyle = faceStyle.Reverse(true)
+	}
+	drawAnalogClock(d.screen, top, bottom, state.Now, faceStyle, d.theme.style(ElementDate))
+
+	for i, label := range labels {
+		drawTextCentered(d.screen, bottom+i, label.text, label.style)
+	}
 }
 
 func normalizeTimeZoneName(location *time.Location) string {
@@ -1,48 +1,164 @@
 package display
 
 import (
+	"slices"
 	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"chrono-ntp/locale"
 
 	"github.com/gdamore/tcell/v2"
 )
 
 var (
 	statusBarQuitLabel    = "Quit"
-	statusBarQuitShortcut = "Q, <C-c>"
+	statusBarHelpLabel    = "Help"
 	statusBarOffsetLabel  = "Offset"
+	statusBarSyncLabel    = "Last Sync"
+	statusBarLeapLabel    = "Leap Seconds"
+	statusBarOfflineLabel = "(offline)"
+	statusBarExpiredLabel = "expired {date}"
+	syncNeverLabel        = "never"
+	syncSecondsAgoLabel   = "{n}s ago"
+	syncMinutesAgoLabel   = "{n}m ago"
+	syncHoursAgoLabel     = "{n}h ago"
+	statusBarCtrlCLabel   = "<C-c>"
+	helpTitle             = "Key Bindings"
+	helpKeyColumnWidth    = 8
+	helpDescriptionIndent = 2
 )
 
-func drawStatusBar(screen tcell.Screen, state DisplayState) {
+func drawStatusBar(screen tcell.Screen, state DisplayState, keyBindings KeyBindings, theme Theme) {
 	_, height := screen.Size()
-	y := height - 1
-
-	for i, r := range statusBarQuitShortcut {
-		screen.SetContent(i, y, r, nil, tcell.StyleDefault.Bold(true).Reverse(true))
+	x, y := 0, height-1
+	if state.HealthBorder {
+		x, y = 1, height-2
 	}
-	x := len(statusBarQuitShortcut) + 1
-	for i, r := range statusBarQuitLabel {
-		screen.SetContent(x+i, y, r, nil, tcell.StyleDefault)
+
+	quitShortcut := formatKeys(keyBindings.Keys(ActionQuit))
+	if quitShortcut != "" {
+		quitShortcut += ", "
 	}
+	quitShortcut += statusBarCtrlCLabel
 
-	x = x + len(statusBarQuitLabel) + 4
-	for i, r := range statusBarOffsetLabel {
-		screen.SetContent(x+i, y, r, nil, tcell.StyleDefault.Bold(true).Reverse(true))
+	labelStyle := theme.style(ElementStatusBarLabel)
+	x = drawShortcut(screen, theme, x, y, quitShortcut, state.Locale.Label(statusBarQuitLabel), labelStyle)
+	if helpShortcut := formatKeys(keyBindings.Keys(ActionToggleHelp)); helpShortcut != "" {
+		x = drawShortcut(screen, theme, x, y, helpShortcut, state.Locale.Label(statusBarHelpLabel), labelStyle)
 	}
 
-	x = x + len(statusBarOffsetLabel) + 1
-	offset := strconv.FormatInt(state.Offset.Milliseconds(), 10) + "ms"
 	if state.Offline {
-		offset = "(offline)"
+		x = drawShortcut(screen, theme, x, y, state.Locale.Label(statusBarOffsetLabel), state.Locale.Label(statusBarOfflineLabel), healthStyle(labelStyle, HealthUnknown))
+	} else {
+		offset := strconv.FormatInt(state.Offset.Milliseconds(), 10) + "ms"
+		syncAgeHealth := ClassifySyncAge(state.LastSync, state.Now)
+		offsetHealth := ClassifyOffset(state.Offset)
+		if syncAgeHealth == HealthUnknown {
+			offsetHealth = HealthUnknown
+		}
+		x = drawShortcut(screen, theme, x, y, state.Locale.Label(statusBarOffsetLabel), offset, healthStyle(labelStyle, offsetHealth))
+		x = drawShortcut(screen, theme, x, y, state.Locale.Label(statusBarSyncLabel), formatSyncAge(state.LastSync, state.Now, state.Locale), healthStyle(labelStyle, syncAgeHealth))
+	}
+
+	// Leap seconds announced after the expiration of the table would be missed
+	if UsesLeapSeconds(state.TimeFormat) && state.LeapSeconds.Expired(state.Now) {
+		expired := strings.ReplaceAll(state.Locale.Label(statusBarExpiredLabel), "{date}", state.LeapSeconds.Expires().Format("2006-01-02"))
+		drawShortcut(screen, theme, x, y, state.Locale.Label(statusBarLeapLabel), expired, healthStyle(labelStyle, HealthWarning))
+	}
+}
+
+// drawShortcut draws a highlighted key followed by its label and returns the x
+// position for the next entry
+func drawShortcut(screen tcell.Screen, theme Theme, x int, y int, key string, label string, labelStyle tcell.Style) int {
+	x = drawText(screen, x, y, key, theme.style(ElementStatusBarKey)) + 1
+	return drawText(screen, x, y, label, labelStyle) + 4
+}
+
+func healthStyle(style tcell.Style, health Health) tcell.Style {
+	return style.Foreground(healthColors[health])
+}
+
+// formatSyncAge returns the time since the last sync in its largest whole unit
+// (e.g. "42s ago", "3m ago") in the language of the locale
+func formatSyncAge(lastSync time.Time, now time.Time, l *locale.Locale) string {
+	if lastSync.IsZero() {
+		return l.Label(syncNeverLabel)
+	}
+	age := max(now.Sub(lastSync), 0)
+	label, n := syncHoursAgoLabel, age/time.Hour
+	switch {
+	case age < time.Minute:
+		label, n = syncSecondsAgoLabel, age/time.Second
+	case age < time.Hour:
+		label, n = syncMinutesAgoLabel, age/time.Minute
 	}
-	for i, r := range offset {
-		screen.SetContent(x+i, y, r, nil, tcell.StyleDefault)
+	return strings.ReplaceAll(l.Label(label), "{n}", strconv.Itoa(int(n)))
+}
+
+// drawHealthBorder draws a frame around the whole screen in the color of the
+// synchronization health
+func drawHealthBorder(screen tcell.Screen, style tcell.Style, health Health) {
+	width, height := screen.Size()
+	style = healthStyle(style, health)
+	for x := 1; x < width-1; x++ {
+		screen.SetContent(x, 0, tcell.RuneHLine, nil, style)
+		screen.SetContent(x, height-1, tcell.RuneHLine, nil, style)
+	}
+	for y := 1; y < height-1; y++ {
+		screen.SetContent(0, y, tcell.RuneVLine, nil, style)
+		screen.SetContent(width-1, y, tcell.RuneVLine, nil, style)
+	}
+	screen.SetContent(0, 0, tcell.RuneULCorner, nil, style)
+	screen.SetContent(width-1, 0, tcell.RuneURCorner, nil, style)
+	screen.SetContent(0, height-1, tcell.RuneLLCorner, nil, style)
+	screen.SetContent(width-1, height-1, tcell.RuneLRCorner, nil, style)
+}
+
+func drawHelp(screen tcell.Screen, keyBindings KeyBindings, theme Theme) {
+	lines := []string{}
+	for _, action := range Actions {
+		keys := formatKeys(keyBindings.Keys(action))
+		if action == ActionQuit {
+			keys = strings.TrimPrefix(keys+", "+statusBarCtrlCLabel, ", ")
+		}
+		if keys == "" {
+			continue
+		}
+		lines = append(lines, keys+strings.Repeat(" ", max(helpKeyColumnWidth-textWidth(keys), 1))+actionDescriptions[action])
+	}
+
+	width := textWidth(helpTitle)
+	for _, line := range lines {
+		width = max(width, textWidth(line))
+	}
+	width += 2 * helpDescriptionIndent
+
+	w, h := screen.Size()
+	left := (w - width) / 2
+	top := (h - len(lines) - 2) / 2
+	for y := top; y < top+len(lines)+2; y++ {
+		for x := left; x < left+width; x++ {
+			screen.SetContent(x, y, ' ', nil, theme.style(ElementHelp))
+		}
+	}
+
+	drawTextCentered(screen, top, helpTitle, theme.style(ElementHelp).Bold(true))
+	for i, line := range lines {
+		drawText(screen, left+helpDescriptionIndent, top+i+2, line, theme.style(ElementHelp))
 	}
 }
 
-func drawTextCentered(s tcell.Screen, y int, text string, style tcell.Style) {
-	w, _ := s.Size()
-	x := (w - len(text)) / 2
-	for i, r := range text {
-		s.SetContent(x+i, y, r, nil, style)
+// formatKeys joins keys for display, showing letters bound in both cases once
+// (in upper case)
+func formatKeys(keys []rune) string {
+	labels := []string{}
+	for _, key := range keys {
+		if unicode.IsLower(key) && slices.Contains(keys, unicode.ToUpper(key)) {
+			continue
+		}
+		labels = append(labels, string(key))
 	}
+	return strings.Join(labels, ", ")
 }
@@ -0,0 +1,66 @@
+package display
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Seconds from the epochs of the epoch formats to the Unix epoch
+const (
+	ntpEpochOffset    = 2208988800  // 1900-01-01, the NTP prime epoch
+	fileTimeOffset    = 11644473600 // 1601-01-01, Windows FILETIME
+	dotNetTicksOffset = 62135596800 // 0001-01-01, .NET DateTime.Ticks
+	cocoaEpochOffset  = -978307200  // 2001-01-01, Apple NSDate reference date
+)
+
+// subSecondEpochFormats are the epoch formats that count units shorter than a
+// second, which change at every redraw
+var subSecondEpochFormats = []string{"unix-ms", "unix-us", "unix-ns", "ntp", "filetime", "dotnet-ticks"}
+
+// formatEpoch returns t in an epoch format, as used in logs and by APIs:
+//
+//   - unix-ms, unix-us, unix-ns: milli-, micro- or nanoseconds since 1970-01-01
+//   - ntp: NTP era and 64-bit timestamp (32-bit seconds since 1900-01-01 and
+//     32-bit fraction) in hex, as shown by ntpq and packet captures
+//   - filetime: Windows FILETIME, 100 ns intervals since 1601-01-01
+//   - dotnet-ticks: .NET DateTime ticks, 100 ns intervals since 0001-01-01
+//   - cocoa: Apple Cocoa (Core Data, NSDate) seconds since 2001-01-01, with
+//     fraction digits (e.g. ".000") like unix
+//
+// All epochs are UTC, and leap seconds are not counted.
+func formatEpoch(t time.Time, timeFormat string, fraction string) string {
+	seconds := t.Unix()
+	nanoseconds := int64(t.Nanosecond())
+	switch timeFormat {
+	case "unix-ms":
+		return fmt.Sprintf("%d", seconds*1e3+nanoseconds/1e6)
+	case "unix-us":
+		return fmt.Sprintf("%d", seconds*1e6+nanoseconds/1e3)
+	case "unix-ns":
+		return fmt.Sprintf("%d", seconds*1e9+nanoseconds)
+	case "ntp":
+		return formatNTPTimestamp(seconds+ntpEpochOffset, nanoseconds)
+	case "filetime":
+		return fmt.Sprintf("%d", (seconds+fileTimeOffset)*1e7+nanoseconds/100)
+	case "dotnet-ticks":
+		return fmt.Sprintf("%d", (seconds+dotNetTicksOffset)*1e7+nanoseconds/100)
+	default: // cocoa
+		return fmt.Sprintf("%d", seconds+cocoaEpochOffset) + t.Format(fraction)
+	}
+}
+
+// formatNTPTimestamp returns the era and the 64-bit fixed point timestamp of
+// the seconds since the NTP prime epoch (e.g. "0/ED5C5A12.80000000"). Era 1
+// starts when the 32-bit seconds wrap around in 2036.
+func formatNTPTimestamp(seconds int64, nanoseconds int64) string {
+	era := seconds >> 32 // Rounds towards negative infinity, like floorDiv
+	fraction := uint64(nanoseconds) << 32 / 1e9
+	return fmt.Sprintf("%d/%08X.%08X", era, uint32(seconds), fraction)
+}
+
+// isSubSecondEpochFormat reports whether the time format counts units shorter
+// than a second
+func isSubSecondEpochFormat(timeFormat string) bool {
+	return slices.Contains(subSecondEpochFormats, timeFormat)
+}
@@ -0,0 +1,53 @@
+package display
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatEpoch(t *testing.T) {
+	instant := time.Date(2023, 10, 1, 15, 16, 17, 123456789, time.UTC)
+	unixEpoch := time.Unix(0, 0).UTC()
+	tests := []struct {
+		format    string
+		t         time.Time
+		precision int
+		expected  string
+	}{
+		{"unix-ms", instant, 0, "1696173377123"},
+		{"unix-ms", unixEpoch, 0, "0"},
+		{"unix-us", instant, 0, "1696173377123456"},
+		{"unix-ns", instant, 0, "1696173377123456789"},
+		// Time zones do not change the count
+		{"unix-ms", instant.In(mustLoadLocation("Asia/Tokyo")), 0, "1696173377123"},
+		{"unix-ms", unixEpoch.Add(-time.Millisecond), 0, "-1"},
+
+		{"ntp", instant, 0, "0/E8C40BC1.1F9ADD37"},
+		{"ntp", unixEpoch, 0, "0/83AA7E80.00000000"},
+		{"ntp", time.Date(1900, 1, 1, 0, 0, 0, 500_000_000, time.UTC), 0, "0/00000000.80000000"},
+		{"ntp", time.Date(1899, 12, 31, 23, 59, 59, 0, time.UTC), 0, "-1/FFFFFFFF.00000000"},
+		// The 32-bit seconds wrap around into era 1
+		{"ntp", time.Date(2036, 2, 7, 6, 28, 15, 0, time.UTC), 0, "0/FFFFFFFF.00000000"},
+		{"ntp", time.Date(2036, 2, 7, 6, 28, 16, 0, time.UTC), 0, "1/00000000.00000000"},
+
+		{"filetime", instant, 0, "133406469771234567"},
+		{"filetime", unixEpoch, 0, "116444736000000000"},
+		{"filetime", time.Date(1601, 1, 1, 0, 0, 0, 0, time.UTC), 0, "0"},
+
+		{"dotnet-ticks", instant, 0, "638317701771234567"},
+		{"dotnet-ticks", unixEpoch, 0, "621355968000000000"},
+		{"dotnet-ticks", time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC), 0, "0"},
+
+		{"cocoa", instant, 0, "717866177"},
+		{"cocoa", instant, 3, "717866177.123"},
+		{"cocoa", time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC), 0, "0"},
+		{"cocoa", unixEpoch, 0, "-978307200"},
+	}
+
+	for _, tt := range tests {
+		got := FormatTimeWithOptions(tt.t, &tt.format, FormatOptions{Precision: tt.precision})
+		if got != tt.expected {
+			t.Errorf("FormatTimeWithOptions(%v, %q, %d): expected '%s', got '%s'", tt.t, tt.format, tt.precision, tt.expected, got)
+		}
+	}
+}
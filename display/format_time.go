@@ -2,13 +2,74 @@ package display
 
 import (
 	"fmt"
+	"math"
+	"slices"
+	"strings"
 	"time"
+
+	"chrono-ntp/astronomy"
+	"chrono-ntp/clock"
+	"chrono-ntp/leapseconds"
+	"chrono-ntp/locale"
+)
+
+var AllowedDateFormats = [...]string{"YYYY-MM-DD", "DD/MM/YYYY", "MM/DD/YYYY", "DD.MM.YYYY", "YYYY-Www-D", "YYYY-DDD", "long", "RFC1123", "DDMMMYY", "locale", "locale-long"}
+var AllowedTimeFormats = [...]string{"ISO8601", "12h", "12h_AM_PM", "dtg", ".beat", "septimal", "decimal", "mars", "lunar", "unix", "unix-ms", "unix-us", "unix-ns", "ntp", "filetime", "cocoa", "dotnet-ticks", "binary", "bcd-column", "hex", "tai", "gps", "loran", "tt", "gmst", "gast", "lst", "jd", "mjd", "rata-die", "excel-serial", "mars-sol-date"}
+
+// otherClockFormats are the time formats whose minutes do not change with the
+// minutes of the wall clock, such as decimal, sidereal and Mars time or the
+// day counts
+var otherClockFormats = []string{".beat", "septimal", "decimal", "lunar", "mars", "hex", "gmst", "gast", "lst", "jd", "mjd", "rata-die", "excel-serial", "mars-sol-date"}
+
+// FollowsWallClock returns whether the minutes of the time format change with
+// those of the wall clock, so the beeps at the end of the minute match the
+// display. The time scales are seconds off because of the leap seconds.
+func FollowsWallClock(timeFormat string) bool {
+	return !slices.Contains(otherClockFormats, timeFormat) && !UsesLeapSeconds(timeFormat)
+}
+
+const (
+	ledOn  = '●'
+	ledOff = '○'
 )
 
-var AllowedDateFormats = [...]string{"YYYY-MM-DD", "DD/MM/YYYY", "MM/DD/YYYY", "DD.MM.YYYY"}
-var AllowedTimeFormats = [...]string{"ISO8601", "12h", "12h_AM_PM", ".beat", "septimal", "mars", "lunar", "unix"}
+// NextDateFormat returns the date format following the given one, wrapping
+// around. A configured custom format is the last one of the cycle.
+func NextDateFormat(dateFormat string, configured string) string {
+	return nextFormat(withCustomFormat(AllowedDateFormats[:], configured), dateFormat)
+}
+
+// NextTimeFormat returns the time format following the given one, wrapping
+// around. A configured custom format is the last one of the cycle.
+func NextTimeFormat(timeFormat string, configured string) string {
+	return nextFormat(withCustomFormat(AllowedTimeFormats[:], configured), timeFormat)
+}
+
+func nextFormat(formats []string, current string) string {
+	return formats[(slices.Index(formats, current)+1)%len(formats)]
+}
+
+// withCustomFormat returns the formats followed by the configured format if it
+// is a custom format, so the cycle comes back to it
+func withCustomFormat(formats []string, configured string) []string {
+	if _, ok := CustomFormatPattern(configured); ok {
+		return slices.Concat(formats, []string{configured})
+	}
+	return formats
+}
 
 func FormatDate(t time.Time, dateFormat *string) string {
+	return FormatDateLocalized(t, dateFormat, nil)
+}
+
+// FormatDateLocalized formats the date like FormatDate, using the order and
+// names of the locale for the locale and locale-long date formats. A nil
+// locale is US English.
+func FormatDateLocalized(t time.Time, dateFormat *string, l *locale.Locale) string {
+	if pattern, ok := CustomFormatPattern(*dateFormat); ok {
+		return formatStrftime(t, pattern, l)
+	}
+
 	switch *dateFormat {
 	case "YYYY-MM-DD":
 		return t.Format("2006-01-02")
@@ -18,58 +79,155 @@ func FormatDate(t time.Time, dateFormat *string) string {
 		return t.Format("01/02/2006")
 	case "DD.MM.YYYY":
 		return t.Format("02.01.2006")
+	case "YYYY-Www-D":
+		// ISO 8601 week date, the year is the week-based year
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d-%d", year, week, clock.ISOWeekday(t))
+	case "YYYY-DDD":
+		// ISO 8601 ordinal date
+		return t.Format("2006-002")
+	case "long":
+		return t.Format("Monday, 2 January 2006")
+	case "RFC1123":
+		// Date part of RFC 1123 (e.g. HTTP headers)
+		return t.Format("Mon, 02 Jan 2006")
+	case "DDMMMYY":
+		// Military date, like the date-time group
+		return strings.ToUpper(t.Format("02Jan06"))
+	case "locale":
+		return l.ShortDate(t)
+	case "locale-long":
+		return l.LongDate(t)
 	default:
 		return t.Format("2006-01-02") // fallback to ISO
 	}
 }
 
+// FormatTime formats the time in the given time format. Some formats (e.g.
+// binary) span multiple rows, which are separated by newlines.
 func FormatTime(t time.Time, timeFormat *string) string {
+	return FormatTimeWithOptions(t, timeFormat, FormatOptions{})
+}
+
+// FormatOptions holds the settings of FormatTimeWithOptions
+type FormatOptions struct {
+	Precision         int                // Fractional second digits (0-3)
+	Locale            *locale.Locale     // For the AM/PM marker, nil is US English
+	LeapSeconds       *leapseconds.Table // For the time scales, nil is the embedded table
+	Longitude         float64            // For local sidereal time, in degrees east
+	MarsLocation      *MarsLocation      // For mars, nil is Coordinated Mars Time
+	DayCountPrecision int                // Decimals of the day counts (jd, mjd, ...)
+}
+
+// FormatTimeWithOptions formats the time like FormatTime with the options. The
+// precision applies to ISO8601, 12h, 12h_AM_PM, unix, cocoa, decimal (of
+// decimal seconds), the tai, gps, loran and tt time scales and the gmst, gast
+// and lst sidereal times; other time formats ignore it.
+func FormatTimeWithOptions(t time.Time, timeFormat *string, options FormatOptions) string {
+	l := options.Locale
+	if pattern, ok := CustomFormatPattern(*timeFormat); ok {
+		return formatStrftime(t, pattern, l)
+	}
+
+	fraction := ""
+	if options.Precision > 0 {
+		fraction = "." + strings.Repeat("0", min(options.Precision, MaxTimePrecision))
+	}
+
 	switch *timeFormat {
+	case "dtg":
+		return formatDTG(t)
 	case ".beat":
 		return formatBeatTime(t)
 	case "septimal":
 		return formatSeptimalTime(t)
+	case "decimal":
+		return formatDecimalTime(t, options.Precision)
 	case "mars":
-		return formatMarsTime(t)
+		return formatMarsTime(t, options.MarsLocation)
 	case "lunar":
 		return formatLunarTime(t)
 	case "unix":
-		return fmt.Sprintf("%d", t.Unix())
+		return fmt.Sprintf("%d", t.Unix()) + t.Format(fraction)
+	case "unix-ms", "unix-us", "unix-ns", "ntp", "filetime", "cocoa", "dotnet-ticks":
+		return formatEpoch(t, *timeFormat, fraction)
+	case "binary":
+		return formatBinaryTime(t)
+	case "bcd-column":
+		return formatBCDColumnTime(t)
+	case "hex":
+		return formatHexTime(t)
+	case "tai", "gps", "loran", "tt":
+		return formatTimeScale(t, *timeFormat, fraction, options.LeapSeconds)
+	case "gmst":
+		return formatSiderealTime(astronomy.GreenwichMeanSiderealTime(t), fraction)
+	case "gast":
+		return formatSiderealTime(astronomy.GreenwichApparentSiderealTime(t), fraction)
+	case "lst":
+		// Local mean sidereal time
+		return formatSiderealTime(astronomy.NormalizeDegrees(astronomy.GreenwichMeanSiderealTime(t)+options.Longitude), fraction)
+	case "jd", "mjd", "rata-die", "excel-serial", "mars-sol-date":
+		return formatDayCount(t, *timeFormat, options.DayCountPrecision)
+	case "12h_AM_PM":
+		return l.WithMeridiem(t, t.Format("03:04:05"+fraction))
 	default:
 		timeFormatMap := map[string]string{
-			"ISO8601":   "15:04:05",
-			"12h":       "03:04:05",
-			"12h_AM_PM": "03:04:05 PM",
+			"ISO8601": "15:04:05" + fraction,
+			"12h":     "03:04:05" + fraction,
 		}
 		return t.Format(timeFormatMap[*timeFormat])
 	}
 }
 
-// formatMarsTime returns Coordinated Mars Time (MTC)
-// See: https://en.wikipedia.org/wiki/Timekeeping_on_Mars
-func formatMarsTime(t time.Time) string {
-	// Julian Date (UTC)
-	y, m, d := t.UTC().Date()
-	h, min, s := t.UTC().Clock()
-	ms := t.UTC().Nanosecond() / 1e6
+// siderealSecond is a second of mean sidereal time, 1/86400 of the 86164.0905
+// SI seconds of a sidereal day
+const siderealSecond = 997269566 * time.Nanosecond
+
+// siderealRedrawIntervals maps a time precision to the interval between
+// redraws for sidereal time, like redrawIntervals
+var siderealRedrawIntervals = [...]time.Duration{
+	siderealSecond,
+	siderealSecond / 10,
+	siderealSecond / 100,
+	siderealSecond / 100,
+}
 
-	if m <= 2 {
-		y -= 1
-		m += 12
+// siderealRedrawInterval returns the interval between redraws for sidereal
+// time with the given precision. Redraws every SI second would skip a
+// sidereal second about every six minutes. The sidereal second boundaries
+// drift against the clock, so the redraws are not aligned to them and the
+// display changes up to one interval late. The redraws of the tick edge
+// indicator are frequent enough to show every sidereal second.
+func siderealRedrawInterval(precision int, tickIndicator bool) time.Duration {
+	if tickIndicator {
+		return RedrawInterval(precision, true)
+	}
+	return siderealRedrawIntervals[max(min(precision, MaxTimePrecision), 0)]
+}
+
+// formatSiderealTime returns the sidereal time of the given angle in degrees
+// as hours, minutes and seconds, with fraction digits (e.g. ".000") of a
+// sidereal second
+func formatSiderealTime(degrees float64, fraction string) string {
+	day := time.Duration(degrees / 360 * float64(24*time.Hour))
+	return time.Time{}.Add(day).Format("15:04:05" + fraction)
+}
+
+// siderealTimeZoneLabel returns the label of a sidereal time format, with the
+// longitude for local sidereal time (e.g. "Local Sidereal Time @ 13.40°E")
+func siderealTimeZoneLabel(timeFormat string, longitude float64) string {
+	switch timeFormat {
+	case "gmst":
+		return "Greenwich Mean Sidereal Time"
+	case "gast":
+		return "Greenwich Apparent Sidereal Time"
+	default:
+		hemisphere := "E"
+		if longitude < 0 {
+			hemisphere = "W"
+		}
+		return fmt.Sprintf("Local Sidereal Time @ %.2f°%s", math.Abs(longitude), hemisphere)
 	}
-	A := y / 100
-	B := 2 - A + A/4
-	jd := 365.25*float64(y+4716) + 30.6001*float64(m+1) + float64(d) + float64(B) - 1524.5
-	fracDay := (float64(h) + float64(min)/60 + float64(s)/3600 + float64(ms)/3600000) / 24.0
-	JD := jd + fracDay
-
-	// Mars Sol Date (MSD)
-	MSD := (JD - 2405522.0028779) / 1.0274912517
-	mtc := 24.0 * (MSD - float64(int(MSD)))
-	hh := int(mtc)
-	mm := int((mtc - float64(hh)) * 60)
-	ss := int((((mtc - float64(hh)) * 60) - float64(mm)) * 60)
-	return fmt.Sprintf("%02d:%02d:%02d", hh, mm, ss)
 }
 
 // formatLunarTime returns Coordinated Lunar Time (LTC)
@@ -120,6 +278,77 @@ func formatBeatTime(t time.Time) string {
 	return fmt.Sprintf("@%s", formatBeat(beat))
 }
 
+// formatBinaryTime returns an LED-style grid with a row each for hours,
+// minutes and seconds, where every decimal digit is shown as four binary
+// coded decimal (BCD) bits
+func formatBinaryTime(t time.Time) string {
+	rows := []string{}
+	for _, n := range []int{t.Hour(), t.Minute(), t.Second()} {
+		rows = append(rows, formatLeds(n/10, 4)+" "+formatLeds(n%10, 4))
+	}
+	return strings.Join(rows, "\n")
+}
+
+// formatBCDColumnTime returns a binary coded decimal (BCD) clock with a column
+// for every digit of hh:mm:ss and the most significant bit at the top. Columns
+// only have as many LEDs as their digit needs (e.g. two for the tens of hours).
+// See: https://en.wikipedia.org/wiki/Binary_clock
+func formatBCDColumnTime(t time.Time) string {
+	h, m, s := t.Clock()
+	digits := []struct {
+		value int
+		bits  int
+	}{
+		{h / 10, 2}, {h % 10, 4},
+		{m / 10, 3}, {m % 10, 4},
+		{s / 10, 3}, {s % 10, 4},
+	}
+
+	rows := []string{}
+	for bit := 3; bit >= 0; bit-- {
+		row := ""
+		for i, digit := range digits {
+			if i > 0 {
+				row += " "
+				if i%2 == 0 {
+					row += " "
+				}
+			}
+			switch {
+			case bit >= digit.bits:
+				row += " "
+			case digit.value&(1<<bit) != 0:
+				row += string(ledOn)
+			default:
+				row += string(ledOff)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return strings.Join(rows, "\n")
+}
+
+func formatLeds(n int, bits int) string {
+	leds := ""
+	for bit := bits - 1; bit >= 0; bit-- {
+		if n&(1<<bit) != 0 {
+			leds += string(ledOn)
+		} else {
+			leds += string(ledOff)
+		}
+	}
+	return leds
+}
+
+// formatHexTime returns hexadecimal time, which divides the day into 65536
+// parts, written as hex hour (1/16 day), maxime and hex second (1/65536 day).
+// Like decimal time, it follows the wall clock, so DST days are not shifted.
+// See: https://en.wikipedia.org/wiki/Hexadecimal_time
+func formatHexTime(t time.Time) string {
+	parts := int(sinceMidnight(t).Seconds() * 65536 / 86400)
+	return fmt.Sprintf("%X_%02X_%X", parts>>12, (parts>>4)&0xff, parts&0xf)
+}
+
 func formatBeat(beat float64) string {
 	// .beat is three digits; we also include centibeats (two digits)
 	// Round down both parts (floor behavior)
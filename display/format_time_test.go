@@ -3,6 +3,8 @@ package display
 import (
 	"testing"
 	"time"
+
+	"chrono-ntp/locale"
 )
 
 func sPtr(s string) *string { return &s }
@@ -33,6 +35,11 @@ func TestFormatDate(t *testing.T) {
 		{"DD/MM/YYYY", "01/10/2023"},
 		{"MM/DD/YYYY", "10/01/2023"},
 		{"DD.MM.YYYY", "01.10.2023"},
+		{"YYYY-Www-D", "2023-W39-7"},
+		{"YYYY-DDD", "2023-274"},
+		{"long", "Sunday, 1 October 2023"},
+		{"RFC1123", "Sun, 01 Oct 2023"},
+		{"DDMMMYY", "01OCT23"},
 	}
 
 	for _, tt := range tests {
@@ -52,11 +59,15 @@ func TestFormatTime(t *testing.T) {
 		{"ISO8601", "15:16:17"},
 		{"12h", "03:16:17"},
 		{"12h_AM_PM", "03:16:17 PM"},
+		{"dtg", "011516ZOCT23"},
 		{".beat", "@677.97"},
 		{"septimal", "43 11 52"},
-		{"mars", "23:42:49"},
+		{"mars", "16:10:25"},
 		{"lunar", "393:56:10"},
 		{"unix", "1696173377"},
+		{"binary", "○○○● ○●○●\n○○○● ○●●○\n○○○● ○●●●"},
+		{"bcd-column", "  ○    ○    ○\n  ●  ○ ●  ○ ●\n○ ○  ○ ●  ○ ●\n● ●  ● ○  ● ●"},
+		{"hex", "A_2E_5"},
 	}
 
 	for _, tt := range tests {
@@ -74,3 +85,264 @@ func TestFormatTime_BeatZeroPadding(t *testing.T) {
 		t.Errorf("Expected '@041.66', got '%s'", result)
 	}
 }
+
+func TestNextTimeFormat(t *testing.T) {
+	tests := []struct {
+		format     string
+		configured string
+		expected   string
+	}{
+		{"ISO8601", "ISO8601", "12h"},
+		{"lunar", "ISO8601", "unix"},
+		{"unix", "ISO8601", "unix-ms"},
+		{"dotnet-ticks", "ISO8601", "binary"},
+		{"hex", "ISO8601", "tai"},
+		{"tt", "ISO8601", "gmst"},
+		{"lst", "ISO8601", "jd"},
+		{"mars-sol-date", "ISO8601", "ISO8601"},
+		{"unknown-format", "ISO8601", "ISO8601"},
+		// The configured custom format stays in the cycle
+		{"custom:%H.%M", "custom:%H.%M", "ISO8601"},
+		{"mars-sol-date", "custom:%H.%M", "custom:%H.%M"},
+	}
+
+	for _, tt := range tests {
+		if got := NextTimeFormat(tt.format, tt.configured); got != tt.expected {
+			t.Errorf("NextTimeFormat(%q, %q): expected '%s', got '%s'", tt.format, tt.configured, tt.expected, got)
+		}
+	}
+}
+
+func TestFollowsWallClock(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected bool
+	}{
+		{"ISO8601", true},
+		{"12h_AM_PM", true},
+		{"dtg", true},
+		{"unix", true},
+		{"unix-ms", true},
+		{"unix-us", true},
+		{"unix-ns", true},
+		{"ntp", true},
+		{"filetime", true},
+		{"cocoa", true},
+		{"dotnet-ticks", true},
+		{"custom:%H:%M", true},
+		{"decimal", false},
+		{"gmst", false},
+		{"mars-sol-date", false},
+		{"tai", false},
+		{"gps", false},
+	}
+
+	for _, tt := range tests {
+		if got := FollowsWallClock(tt.format); got != tt.expected {
+			t.Errorf("FollowsWallClock(%q): expected %v, got %v", tt.format, tt.expected, got)
+		}
+	}
+}
+
+func TestFormatDate_ISOWeekYear(t *testing.T) {
+	tests := []struct {
+		date     time.Time
+		expected string
+	}{
+		// Belongs to the last week of the previous year
+		{time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), "2020-W53-5"},
+		// Belongs to the first week of the next year
+		{time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC), "2025-W01-1"},
+		{time.Date(2024, 12, 29, 0, 0, 0, 0, time.UTC), "2024-W52-7"},
+	}
+
+	format := "YYYY-Www-D"
+	for _, tt := range tests {
+		if got := FormatDate(tt.date, &format); got != tt.expected {
+			t.Errorf("FormatDate(%v): expected '%s', got '%s'", tt.date, tt.expected, got)
+		}
+	}
+
+	ordinal := "YYYY-DDD"
+	if got := FormatDate(time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), &ordinal); got != "2024-366" {
+		t.Errorf("FormatDate(leap year): expected '2024-366', got '%s'", got)
+	}
+}
+
+func TestNextDateFormat(t *testing.T) {
+	if got := NextDateFormat("YYYY-MM-DD", "YYYY-MM-DD"); got != "DD/MM/YYYY" {
+		t.Errorf("Expected 'DD/MM/YYYY', got '%s'", got)
+	}
+	if got := NextDateFormat("DD.MM.YYYY", "YYYY-MM-DD"); got != "YYYY-Www-D" {
+		t.Errorf("Expected 'YYYY-Www-D', got '%s'", got)
+	}
+	if got := NextDateFormat("locale-long", "YYYY-MM-DD"); got != "YYYY-MM-DD" {
+		t.Errorf("Expected 'YYYY-MM-DD', got '%s'", got)
+	}
+	if got := NextDateFormat("locale-long", "custom:%d %b"); got != "custom:%d %b" {
+		t.Errorf("Expected 'custom:%%d %%b', got '%s'", got)
+	}
+}
+
+func TestFormatLocalized(t *testing.T) {
+	inputTime := time.Date(2023, 10, 3, 15, 16, 17, 0, time.UTC)
+	tests := []struct {
+		locale     string
+		dateFormat string
+		timeFormat string
+		date       string
+		time       string
+	}{
+		{"en-US", "locale", "12h_AM_PM", "10/03/2023", "03:16:17 PM"},
+		{"en-GB", "locale-long", "12h_AM_PM", "Tuesday, 3 October 2023", "03:16:17 pm"},
+		{"de-DE", "locale-long", "ISO8601", "Dienstag, 3. Oktober 2023", "15:16:17"},
+		{"de-DE", "locale", "12h_AM_PM", "03.10.2023", "03:16:17 PM"},
+		{"fr-FR", "locale-long", "ISO8601", "mardi 3 octobre 2023", "15:16:17"},
+		{"es-ES", "locale-long", "12h_AM_PM", "martes, 3 de octubre de 2023", "03:16:17 p. m."},
+		{"ja-JP", "locale-long", "12h_AM_PM", "2023年10月3日 火曜日", "午後 03:16:17"},
+		{"zh-CN", "locale", "12h_AM_PM", "2023/10/03", "下午 03:16:17"},
+		{"ja-JP", "YYYY-MM-DD", "ISO8601", "2023-10-03", "15:16:17"},
+	}
+
+	for _, tt := range tests {
+		l, _ := locale.Lookup(tt.locale)
+		if got := FormatDateLocalized(inputTime, &tt.dateFormat, l); got != tt.date {
+			t.Errorf("FormatDateLocalized(%q, %q): expected '%s', got '%s'", tt.dateFormat, tt.locale, tt.date, got)
+		}
+		if got := FormatTimeWithOptions(inputTime, &tt.timeFormat, FormatOptions{Locale: l}); got != tt.time {
+			t.Errorf("FormatTimeWithOptions(%q, %q): expected '%s', got '%s'", tt.timeFormat, tt.locale, tt.time, got)
+		}
+	}
+}
+
+func TestFormatTimeWithOptions_Precision(t *testing.T) {
+	inputTime := time.Date(2023, 10, 1, 15, 16, 17, 987654321, time.UTC)
+	tests := []struct {
+		format    string
+		precision int
+		expected  string
+	}{
+		{"ISO8601", 0, "15:16:17"},
+		{"ISO8601", 1, "15:16:17.9"},
+		{"ISO8601", 2, "15:16:17.98"},
+		{"ISO8601", 3, "15:16:17.987"},
+		{"ISO8601", 9, "15:16:17.987"},
+		{"12h", 2, "03:16:17.98"},
+		{"12h_AM_PM", 3, "03:16:17.987 PM"},
+		{"unix", 0, "1696173377"},
+		{"unix", 3, "1696173377.987"},
+		{".beat", 3, "@677.97"},
+		{"mars", 3, "16:10:26"},
+	}
+
+	for _, tt := range tests {
+		format := tt.format
+		result := FormatTimeWithOptions(inputTime, &format, FormatOptions{Precision: tt.precision})
+		if result != tt.expected {
+			t.Errorf("FormatTimeWithOptions(%s, %d): got %s, want %s", tt.format, tt.precision, result, tt.expected)
+		}
+	}
+}
+
+func TestFormatTime_Binary(t *testing.T) {
+	tests := []struct {
+		time     time.Time
+		expected string
+	}{
+		{time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC), "○○○○ ○○○○\n○○○○ ○○○○\n○○○○ ○○○○"},
+		{time.Date(2023, 10, 1, 23, 59, 59, 0, time.UTC), "○○●○ ○○●●\n○●○● ●○○●\n○●○● ●○○●"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatTime(tt.time, sPtr("binary")); got != tt.expected {
+			t.Errorf("FormatTime(%v, binary): expected\n%s\ngot\n%s", tt.time, tt.expected, got)
+		}
+	}
+}
+
+func TestFormatTime_BCDColumn(t *testing.T) {
+	expected := "" +
+		"  ○    ●    ●\n" +
+		"  ○  ● ○  ● ○\n" +
+		"● ●  ○ ○  ○ ○\n" +
+		"○ ●  ● ●  ● ●"
+	if got := FormatTime(time.Date(2023, 10, 1, 23, 59, 59, 0, time.UTC), sPtr("bcd-column")); got != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, got)
+	}
+}
+
+func TestFormatTime_Hex(t *testing.T) {
+	tests := []struct {
+		time     time.Time
+		expected string
+	}{
+		{time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC), "0_00_0"},
+		{time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC), "8_00_0"},
+		{time.Date(2023, 10, 1, 18, 0, 0, 0, time.UTC), "C_00_0"},
+		{time.Date(2023, 10, 1, 23, 59, 59, 0, time.UTC), "F_FF_F"},
+		// Spring forward: 3 hours on the wall clock, 2 hours since midnight
+		{time.Date(2026, 3, 29, 3, 0, 0, 0, mustLoadLocation("Europe/Berlin")), "2_00_0"},
+		// Fall back: 12 and 23 hours on the wall clock, 13 and 24 hours since
+		// midnight
+		{time.Date(2026, 10, 25, 12, 0, 0, 0, mustLoadLocation("Europe/Berlin")), "8_00_0"},
+		{time.Date(2026, 10, 25, 23, 0, 0, 0, mustLoadLocation("Europe/Berlin")), "F_55_5"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatTime(tt.time, sPtr("hex")); got != tt.expected {
+			t.Errorf("FormatTime(%v, hex): expected '%s', got '%s'", tt.time, tt.expected, got)
+		}
+	}
+}
+
+func TestFormatSiderealTime(t *testing.T) {
+	tests := []struct {
+		time      time.Time
+		format    string
+		longitude float64
+		expected  string
+	}{
+		// Meeus, Astronomical Algorithms, example 12.a: 13h10m46.3668s, apparent 13h10m46.1351s
+		{time.Date(1987, 4, 10, 0, 0, 0, 0, time.UTC), "gmst", 0, "13:10:46.366"},
+		{time.Date(1987, 4, 10, 0, 0, 0, 0, time.UTC), "gast", 0, "13:10:46.1"},
+		// Meeus, example 12.b: 8h34m57.0896s
+		{time.Date(1987, 4, 10, 19, 21, 0, 0, time.UTC), "gmst", 0, "08:34:57.089"},
+		// Astronomical Almanac: 6h39m52.2707s at J2000.0 - 12h
+		{time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), "gmst", 0, "06:39:52.270"},
+		// Local sidereal time is ahead by 4 minutes per degree east
+		{time.Date(1987, 4, 10, 0, 0, 0, 0, time.UTC), "lst", 15, "14:10:46.366"},
+		{time.Date(1987, 4, 10, 19, 21, 0, 0, time.UTC), "lst", -77.0656, "03:26:41.345"},
+		{time.Date(1987, 4, 10, 0, 0, 0, 0, time.UTC), "lst", 0, "13:10:46.366"},
+	}
+
+	for _, tt := range tests {
+		precision := 3
+		if tt.format == "gast" {
+			precision = 1 // The nutation is only computed to about 0.5"
+		}
+		got := FormatTimeWithOptions(tt.time, &tt.format, FormatOptions{Precision: precision, Longitude: tt.longitude})
+		if got != tt.expected {
+			t.Errorf("FormatTimeWithOptions(%v, %q, %g): expected '%s', got '%s'", tt.time, tt.format, tt.longitude, tt.expected, got)
+		}
+	}
+}
+
+func TestSiderealTimeZoneLabel(t *testing.T) {
+	tests := []struct {
+		format    string
+		longitude float64
+		expected  string
+	}{
+		{"gmst", 13.4, "Greenwich Mean Sidereal Time"},
+		{"gast", 13.4, "Greenwich Apparent Sidereal Time"},
+		{"lst", 13.405, "Local Sidereal Time @ 13.40°E"},
+		{"lst", -77.0656, "Local Sidereal Time @ 77.07°W"},
+		{"lst", 0, "Local Sidereal Time @ 0.00°E"},
+	}
+
+	for _, tt := range tests {
+		if got := siderealTimeZoneLabel(tt.format, tt.longitude); got != tt.expected {
+			t.Errorf("siderealTimeZoneLabel(%q, %g): expected '%s', got '%s'", tt.format, tt.longitude, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package display
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+var sparklineLevels = []rune("▁▂▃▄▅▆▇█")
+
+const (
+	graphWidth      = 40 // Maximum number of samples shown
+	graphLabelWidth = 7
+	graphRows       = 3
+)
+
+type seriesSummary struct {
+	min    time.Duration
+	max    time.Duration
+	jitter time.Duration // Root mean square of the differences between successive values
+}
+
+func summarize(values []time.Duration) seriesSummary {
+	if len(values) == 0 {
+		return seriesSummary{}
+	}
+
+	summary := seriesSummary{min: values[0], max: values[0]}
+	sumOfSquares := 0.0
+	for i, value := range values {
+		summary.min = min(summary.min, value)
+		summary.max = max(summary.max, value)
+		if i > 0 {
+			diff := float64(value - values[i-1])
+			sumOfSquares += diff * diff
+		}
+	}
+	if len(values) > 1 {
+		summary.jitter = time.Duration(math.Sqrt(sumOfSquares / float64(len(values)-1)))
+	}
+	return summary
+}
+
+// sparkline returns one block character per value, scaled between the
+// minimum and maximum value
+func sparkline(values []time.Duration) string {
+	summary := summarize(values)
+	valueRange := float64(summary.max - summary.min)
+
+	runes := make([]rune, len(values))
+	for i, value := range values {
+		level := 0
+		if valueRange > 0 {
+			level = int(float64(value-summary.min) / valueRange * float64(len(sparklineLevels)-1))
+		}
+		runes[i] = sparklineLevels[level]
+	}
+	return string(runes)
+}
+
+// formatGraphRow returns a labelled sparkline of the most recent values, right
+// aligned so the newest values of all rows line up, with min, max and jitter
+func formatGraphRow(label string, values []time.Duration) string {
+	values = values[max(len(values)-graphWidth, 0):]
+	summary := summarize(values)
+	return fmt.Sprintf("%-*s%*s  min %s  max %s  jitter %s",
+		graphLabelWidth, label,
+		graphWidth, sparkline(values),
+		formatMilliseconds(summary.min), formatMilliseconds(summary.max), formatMilliseconds(summary.jitter),
+	)
+}
+
+// formatGraphSpan returns how many of the most recent samples the graph shows
+// and the time between the first and the last of them
+func formatGraphSpan(times []time.Time) string {
+	times = times[max(len(times)-graphWidth, 0):]
+	switch len(times) {
+	case 0:
+		return "No samples"
+	case 1:
+		return "1 sample"
+	}
+	return fmt.Sprintf("%d samples over %s", len(times), formatCountdown(times[len(times)-1].Sub(times[0])))
+}
+
+func formatMilliseconds(d time.Duration) string {
+	return fmt.Sprintf("%.1fms", float64(d)/float64(time.Millisecond))
+}
+
+// drawGraph draws the offset and round-trip time history starting at row y,
+// followed by the time it covers
+func drawGraph(screen tcell.Screen, y int, times []time.Time, offsets []time.Duration, rtts []time.Duration, style tcell.Style) {
+	if len(offsets) == 0 {
+		drawTextCentered(screen, y, "No NTP samples", style)
+		return
+	}
+
+	rows := []string{formatGraphRow("Offset", offsets), formatGraphRow("RTT", rtts), formatGraphSpan(times)}
+	width := 0
+	for _, row := range rows {
+		width = max(width, textWidth(row))
+	}
+	screenWidth, _ := screen.Size()
+	for i, row := range rows {
+		drawText(screen, (screenWidth-width)/2, y+i, row, style)
+	}
+}
@@ -0,0 +1,102 @@
+package display
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func ms(values ...float64) []time.Duration {
+	durations := []time.Duration{}
+	for _, value := range values {
+		durations = append(durations, time.Duration(value*float64(time.Millisecond)))
+	}
+	return durations
+}
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		values   []time.Duration
+		expected seriesSummary
+	}{
+		{nil, seriesSummary{}},
+		{ms(5), seriesSummary{min: ms(5)[0], max: ms(5)[0]}},
+		{ms(1, -3, 2), seriesSummary{min: ms(-3)[0], max: ms(2)[0], jitter: ms(4.527692)[0]}},
+		{ms(2, 2, 2), seriesSummary{min: ms(2)[0], max: ms(2)[0]}},
+	}
+
+	for _, tt := range tests {
+		got := summarize(tt.values)
+		if got.min != tt.expected.min || got.max != tt.expected.max || (got.jitter-tt.expected.jitter).Abs() > time.Microsecond {
+			t.Errorf("summarize(%v): expected %+v, got %+v", tt.values, tt.expected, got)
+		}
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		values   []time.Duration
+		expected string
+	}{
+		{nil, ""},
+		{ms(3, 3, 3), "▁▁▁"},
+		{ms(0, 7), "▁█"},
+		{ms(-10, -5, 0, 5, 10), "▁▂▄▆█"},
+		{ms(0, 1, 2, 3, 4, 5, 6, 7), "▁▂▃▄▅▆▇█"},
+	}
+
+	for _, tt := range tests {
+		if got := sparkline(tt.values); got != tt.expected {
+			t.Errorf("sparkline(%v): expected '%s', got '%s'", tt.values, tt.expected, got)
+		}
+	}
+}
+
+func TestFormatGraphRow(t *testing.T) {
+	got := formatGraphRow("RTT", ms(10, 12.5, 11))
+	expected := "RTT    " + strings.Repeat(" ", graphWidth-3) + "▁█▃  min 10.0ms  max 12.5ms  jitter 2.1ms"
+	if got != expected {
+		t.Errorf("expected '%s', got '%s'", expected, got)
+	}
+}
+
+func TestFormatGraphRow_OnlyRecentValues(t *testing.T) {
+	values := ms(100)
+	for range graphWidth {
+		values = append(values, ms(1)...)
+	}
+
+	got := formatGraphRow("Offset", values)
+	if !strings.Contains(got, "max 1.0ms") {
+		t.Errorf("expected only the most recent %d values, got '%s'", graphWidth, got)
+	}
+}
+
+func TestFormatGraphSpan(t *testing.T) {
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	every := func(n int, interval time.Duration) []time.Time {
+		times := []time.Time{}
+		for i := range n {
+			times = append(times, start.Add(time.Duration(i)*interval))
+		}
+		return times
+	}
+
+	tests := []struct {
+		times    []time.Time
+		expected string
+	}{
+		{nil, "No samples"},
+		{every(1, 0), "1 sample"},
+		{every(3, 30*time.Second), "3 samples over 1m 00s"},
+		// Only the samples shown in the graph
+		{every(120, 30*time.Second), "40 samples over 19m 30s"},
+		{every(graphWidth, 15*time.Minute), "40 samples over 9h 45m"},
+	}
+
+	for _, tt := range tests {
+		if got := formatGraphSpan(tt.times); got != tt.expected {
+			t.Errorf("formatGraphSpan(%d times): expected '%s', got '%s'", len(tt.times), tt.expected, got)
+		}
+	}
+}
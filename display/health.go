@@ -0,0 +1,73 @@
+package display
+
+import (
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+type Health int
+
+const (
+	HealthUnknown Health = iota // Offline or stale, the offset cannot be trusted
+	HealthGood
+	HealthWarning
+	HealthCritical
+)
+
+const (
+	offsetWarningThreshold  = 10 * time.Millisecond
+	offsetCriticalThreshold = 100 * time.Millisecond
+	syncAgeWarningThreshold = 20 * time.Minute // Longer than the NTP offset refresh interval
+	syncAgeStaleThreshold   = time.Hour
+)
+
+var healthColors = map[Health]tcell.Color{
+	HealthUnknown:  tcell.ColorGray,
+	HealthGood:     tcell.ColorGreen,
+	HealthWarning:  tcell.ColorYellow,
+	HealthCritical: tcell.ColorRed,
+}
+
+// ClassifyOffset returns the health of an offset from the NTP server
+func ClassifyOffset(offset time.Duration) Health {
+	offset = offset.Abs()
+	switch {
+	case offset < offsetWarningThreshold:
+		return HealthGood
+	case offset < offsetCriticalThreshold:
+		return HealthWarning
+	default:
+		return HealthCritical
+	}
+}
+
+// ClassifySyncAge returns the health of the last successful synchronization
+// with the NTP server; a zero lastSync means it never succeeded
+func ClassifySyncAge(lastSync time.Time, now time.Time) Health {
+	if lastSync.IsZero() {
+		return HealthUnknown
+	}
+	age := now.Sub(lastSync)
+	switch {
+	case age < syncAgeWarningThreshold:
+		return HealthGood
+	case age < syncAgeStaleThreshold:
+		return HealthWarning
+	default:
+		return HealthUnknown
+	}
+}
+
+// ClassifyHealth returns the overall synchronization health, which is the
+// worst of the offset and last sync age health
+func ClassifyHealth(state DisplayState) Health {
+	if state.Offline {
+		return HealthUnknown
+	}
+	syncAgeHealth := ClassifySyncAge(state.LastSync, state.Now)
+	if syncAgeHealth == HealthUnknown {
+		return HealthUnknown
+	}
+	return max(ClassifyOffset(state.Offset), syncAgeHealth)
+}
@@ -0,0 +1,97 @@
+package display
+
+import (
+	"testing"
+	"time"
+
+	"chrono-ntp/locale"
+)
+
+func TestClassifyOffset(t *testing.T) {
+	tests := []struct {
+		offset   time.Duration
+		expected Health
+	}{
+		{0, HealthGood},
+		{9 * time.Millisecond, HealthGood},
+		{-9 * time.Millisecond, HealthGood},
+		{10 * time.Millisecond, HealthWarning},
+		{-50 * time.Millisecond, HealthWarning},
+		{100 * time.Millisecond, HealthCritical},
+		{-2 * time.Second, HealthCritical},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyOffset(tt.offset); got != tt.expected {
+			t.Errorf("ClassifyOffset(%v): expected %v, got %v", tt.offset, tt.expected, got)
+		}
+	}
+}
+
+func TestClassifySyncAge(t *testing.T) {
+	now := time.Date(2025, 11, 11, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		lastSync time.Time
+		expected Health
+	}{
+		{time.Time{}, HealthUnknown},
+		{now, HealthGood},
+		{now.Add(-15 * time.Minute), HealthGood},
+		{now.Add(-20 * time.Minute), HealthWarning},
+		{now.Add(-59 * time.Minute), HealthWarning},
+		{now.Add(-time.Hour), HealthUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifySyncAge(tt.lastSync, now); got != tt.expected {
+			t.Errorf("ClassifySyncAge(%v): expected %v, got %v", tt.lastSync, tt.expected, got)
+		}
+	}
+}
+
+func TestClassifyHealth(t *testing.T) {
+	now := time.Date(2025, 11, 11, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		state    DisplayState
+		expected Health
+	}{
+		{DisplayState{Now: now, LastSync: now, Offset: time.Millisecond}, HealthGood},
+		{DisplayState{Now: now, LastSync: now, Offset: time.Millisecond, Offline: true}, HealthUnknown},
+		{DisplayState{Now: now, LastSync: now, Offset: 20 * time.Millisecond}, HealthWarning},
+		{DisplayState{Now: now, LastSync: now.Add(-30 * time.Minute), Offset: time.Millisecond}, HealthWarning},
+		{DisplayState{Now: now, LastSync: now.Add(-30 * time.Minute), Offset: time.Second}, HealthCritical},
+		{DisplayState{Now: now, LastSync: now.Add(-2 * time.Hour), Offset: time.Second}, HealthUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyHealth(tt.state); got != tt.expected {
+			t.Errorf("ClassifyHealth(%+v): expected %v, got %v", tt.state, tt.expected, got)
+		}
+	}
+}
+
+func TestFormatSyncAge(t *testing.T) {
+	now := time.Date(2025, 11, 11, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		lastSync time.Time
+		locale   string
+		expected string
+	}{
+		{time.Time{}, "", "never"},
+		{now.Add(time.Second), "", "0s ago"},
+		{now.Add(-42 * time.Second), "", "42s ago"},
+		{now.Add(-3*time.Minute - 59*time.Second), "", "3m ago"},
+		{now.Add(-2 * time.Hour), "", "2h ago"},
+		{time.Time{}, "de-DE", "nie"},
+		{now.Add(-42 * time.Second), "de-DE", "vor 42 s"},
+		{now.Add(-3 * time.Minute), "de-DE", "vor 3 min"},
+		{now.Add(-2 * time.Hour), "ja-JP", "2時間前"},
+	}
+
+	for _, tt := range tests {
+		l, _ := locale.Lookup(tt.locale) // Empty is the default locale
+		if got := formatSyncAge(tt.lastSync, now, l); got != tt.expected {
+			t.Errorf("formatSyncAge(%v, %q): expected '%s', got '%s'", tt.lastSync, tt.locale, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package display
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"chrono-ntp/locale"
+)
+
+// hebrewCalendar is the lunisolar Hebrew calendar, with 7 leap years of 13
+// months in 19 years. The length of a year follows from the mean new moon
+// (molad) of Tishrei and the postponement rules (dehiyyot).
+// See: https://en.wikipedia.org/wiki/Hebrew_calendar
+type hebrewCalendar struct{}
+
+// hebrewEpoch is the fixed day of 1 Tishrei 1 AM (7 October 3761 BC in the
+// Julian calendar)
+const hebrewEpoch = -1373427
+
+// Months are numbered from Nisan, as in the Bible; the year starts in Tishrei
+// (month 7). Adar is Adar I in leap years, followed by Adar II (month 13).
+const (
+	nisan   = 1
+	tishrei = 7
+	adar    = 12
+	adarII  = 13
+)
+
+var hebrewMonths = [13]string{
+	"Nisan", "Iyar", "Sivan", "Tammuz", "Av", "Elul",
+	"Tishrei", "Cheshvan", "Kislev", "Tevet", "Shevat", "Adar", "Adar II",
+}
+
+func (hebrewCalendar) Name() string {
+	return "hebrew"
+}
+
+func (hebrewCalendar) FormatDate(t time.Time, _ *locale.Locale) string {
+	year, month, day := hebrewFromFixed(fixedDay(t))
+	name := hebrewMonths[month-1]
+	if month == adar && isHebrewLeapYear(year) {
+		name = "Adar I"
+	}
+	return fmt.Sprintf("%d %s %d", day, name, year)
+}
+
+func isHebrewLeapYear(year int) bool {
+	return floorMod(7*year+1, 19) < 7
+}
+
+func lastHebrewMonth(year int) int {
+	if isHebrewLeapYear(year) {
+		return adarII
+	}
+	return adar
+}
+
+// hebrewCalendarElapsedDays returns the number of days from the epoch to the
+// molad of Tishrei of the year, postponed when the molad falls on a Sunday,
+// Wednesday or Friday
+func hebrewCalendarElapsedDays(year int) int {
+	monthsElapsed := floorDiv(235*year-234, 19)
+	partsElapsed := 12084 + 13753*monthsElapsed // 1080 parts (halakim) per hour
+	days := 29*monthsElapsed + floorDiv(partsElapsed, 25920)
+	if floorMod(3*(days+1), 7) < 3 {
+		days++
+	}
+	return days
+}
+
+// hebrewYearLengthCorrection returns the further postponement of the new
+// year, which keeps the lengths of the years between 353 and 385 days
+func hebrewYearLengthCorrection(year int) int {
+	previous := hebrewCalendarElapsedDays(year - 1)
+	current := hebrewCalendarElapsedDays(year)
+	next := hebrewCalendarElapsedDays(year + 1)
+	switch {
+	case next-current == 356:
+		return 2
+	case current-previous == 382:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// hebrewNewYear returns the fixed day of 1 Tishrei (Rosh Hashanah) of the year
+func hebrewNewYear(year int) int {
+	return hebrewEpoch + hebrewCalendarElapsedDays(year) + hebrewYearLengthCorrection(year)
+}
+
+func hebrewYearDays(year int) int {
+	return hebrewNewYear(year+1) - hebrewNewYear(year)
+}
+
+// lastDayOfHebrewMonth returns 29 or 30. Cheshvan and Kislev vary with the
+// length of the year.
+func lastDayOfHebrewMonth(year int, month int) int {
+	days := hebrewYearDays(year)
+	switch {
+	case month == 2 || month == 4 || month == 6 || month == 10 || month == adarII,
+		month == adar && !isHebrewLeapYear(year),
+		month == 8 && days != 355 && days != 385,
+		month == 9 && (days == 353 || days == 383):
+		return 29
+	default:
+		return 30
+	}
+}
+
+func fixedFromHebrew(year int, month int, day int) int {
+	fixed := hebrewNewYear(year) + day - 1
+	if month < tishrei {
+		for m := tishrei; m <= lastHebrewMonth(year); m++ {
+			fixed += lastDayOfHebrewMonth(year, m)
+		}
+		for m := nisan; m < month; m++ {
+			fixed += lastDayOfHebrewMonth(year, m)
+		}
+	} else {
+		for m := tishrei; m < month; m++ {
+			fixed += lastDayOfHebrewMonth(year, m)
+		}
+	}
+	return fixed
+}
+
+func hebrewFromFixed(day int) (year int, month int, dayOfMonth int) {
+	// Estimate the year from the mean length of a year, 35975351/98496 days
+	year = int(math.Floor(float64(day-hebrewEpoch) / (35975351.0 / 98496)))
+	for hebrewNewYear(year+1) <= day {
+		year++
+	}
+	for hebrewNewYear(year) > day {
+		year--
+	}
+	month = tishrei
+	if day >= fixedFromHebrew(year, nisan, 1) {
+		month = nisan
+	}
+	for day > fixedFromHebrew(year, month, lastDayOfHebrewMonth(year, month)) {
+		month++
+	}
+	return year, month, day - fixedFromHebrew(year, month, 1) + 1
+}
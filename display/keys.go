@@ -0,0 +1,116 @@
+package display
+
+import (
+	"fmt"
+	"slices"
+	"unicode/utf8"
+)
+
+type Action string
+
+const (
+	ActionQuit            Action = "quit"
+	ActionNextMode        Action = "next-mode"
+	ActionNextTimeFormat  Action = "next-time-format"
+	ActionNextDateFormat  Action = "next-date-format"
+	ActionToggleDate      Action = "toggle-date"
+	ActionToggleTimeZone  Action = "toggle-time-zone"
+	ActionToggleStatusBar Action = "toggle-status-bar"
+	ActionToggleGraph     Action = "toggle-graph"
+	ActionToggleCalendar  Action = "toggle-calendar"
+	ActionToggleAstronomy Action = "toggle-astronomy"
+	ActionToggleBeeps     Action = "toggle-beeps"
+	ActionRefreshNtp      Action = "refresh-ntp"
+	ActionToggleHelp      Action = "help"
+)
+
+// Actions lists all actions in the order they are shown in the help overlay
+var Actions = [...]Action{
+	ActionNextMode,
+	ActionNextTimeFormat,
+	ActionNextDateFormat,
+	ActionToggleDate,
+	ActionToggleTimeZone,
+	ActionToggleStatusBar,
+	ActionToggleGraph,
+	ActionToggleCalendar,
+	ActionToggleAstronomy,
+	ActionToggleBeeps,
+	ActionRefreshNtp,
+	ActionToggleHelp,
+	ActionQuit,
+}
+
+var actionDescriptions = map[Action]string{
+	ActionQuit:            "Quit",
+	ActionNextMode:        "Next display mode",
+	ActionNextTimeFormat:  "Next time format",
+	ActionNextDateFormat:  "Next date format",
+	ActionToggleDate:      "Toggle date",
+	ActionToggleTimeZone:  "Toggle time zone",
+	ActionToggleStatusBar: "Toggle status bar",
+	ActionToggleGraph:     "Toggle offset history graph",
+	ActionToggleCalendar:  "Toggle calendar",
+	ActionToggleAstronomy: "Toggle sun and moon",
+	ActionToggleBeeps:     "Toggle beeps",
+	ActionRefreshNtp:      "Refresh NTP offset",
+	ActionToggleHelp:      "Toggle help",
+}
+
+type KeyBindings map[rune]Action
+
+var defaultKeyBindings = KeyBindings{
+	'q': ActionQuit,
+	'Q': ActionQuit,
+	'm': ActionNextMode,
+	't': ActionNextTimeFormat,
+	'd': ActionNextDateFormat,
+	'D': ActionToggleDate,
+	'z': ActionToggleTimeZone,
+	's': ActionToggleStatusBar,
+	'g': ActionToggleGraph,
+	'c': ActionToggleCalendar,
+	'a': ActionToggleAstronomy,
+	'b': ActionToggleBeeps,
+	'r': ActionRefreshNtp,
+	'?': ActionToggleHelp,
+}
+
+// NewKeyBindings returns the default key bindings with the given overrides
+// (action name to key) applied. Remapping an action removes its default keys.
+func NewKeyBindings(overrides map[string]string) (KeyBindings, error) {
+	bindings := KeyBindings{}
+	for key, action := range defaultKeyBindings {
+		if _, ok := overrides[string(action)]; !ok {
+			bindings[key] = action
+		}
+	}
+
+	for name, key := range overrides {
+		action := Action(name)
+		if _, ok := actionDescriptions[action]; !ok {
+			return nil, fmt.Errorf("unknown action '%s'", name)
+		}
+		if utf8.RuneCountInString(key) != 1 {
+			return nil, fmt.Errorf("invalid key '%s' for action '%s': must be a single character", key, name)
+		}
+		r, _ := utf8.DecodeRuneInString(key)
+		if existing, ok := bindings[r]; ok {
+			return nil, fmt.Errorf("key '%s' for action '%s' is already bound to '%s'", key, name, existing)
+		}
+		bindings[r] = action
+	}
+	return bindings, nil
+}
+
+// Keys returns the keys bound to the given action, in ascending order
+func (b KeyBindings) Keys(action Action) []rune {
+	var keys []rune
+	for key, a := range b {
+		if a == action {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
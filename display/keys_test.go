@@ -0,0 +1,74 @@
+package display
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewKeyBindings_Defaults(t *testing.T) {
+	bindings, err := NewKeyBindings(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(bindings, defaultKeyBindings) {
+		t.Errorf("expected default key bindings, got %v", bindings)
+	}
+	for _, action := range Actions {
+		if len(bindings.Keys(action)) == 0 {
+			t.Errorf("expected a default key for action %q", action)
+		}
+	}
+}
+
+func TestNewKeyBindings_Overrides(t *testing.T) {
+	bindings, err := NewKeyBindings(map[string]string{
+		"quit":             "x",
+		"next-time-format": "ä",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if keys := bindings.Keys(ActionQuit); !reflect.DeepEqual(keys, []rune{'x'}) {
+		t.Errorf("expected quit to be bound to 'x' only, got %q", keys)
+	}
+	if keys := bindings.Keys(ActionNextTimeFormat); !reflect.DeepEqual(keys, []rune{'ä'}) {
+		t.Errorf("expected next-time-format to be bound to 'ä' only, got %q", keys)
+	}
+	if action := bindings['d']; action != ActionNextDateFormat {
+		t.Errorf("expected 'd' to keep its default binding, got %q", action)
+	}
+}
+
+func TestNewKeyBindings_Errors(t *testing.T) {
+	tests := []map[string]string{
+		{"unknown-action": "x"},
+		{"quit": ""},
+		{"quit": "xy"},
+		{"quit": "t"},
+	}
+
+	for _, overrides := range tests {
+		if _, err := NewKeyBindings(overrides); err == nil {
+			t.Errorf("NewKeyBindings(%v): expected error", overrides)
+		}
+	}
+}
+
+func TestFormatKeys(t *testing.T) {
+	tests := []struct {
+		keys     []rune
+		expected string
+	}{
+		{nil, ""},
+		{[]rune{'Q', 'q'}, "Q"},
+		{[]rune{'?'}, "?"},
+		{[]rune{'D', 'd', 'x'}, "D, x"},
+	}
+
+	for _, tt := range tests {
+		if got := formatKeys(tt.keys); got != tt.expected {
+			t.Errorf("formatKeys(%q): expected '%s', got '%s'", tt.keys, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package display
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"chrono-ntp/astronomy"
+)
+
+const (
+	// Mars Sol Date (MSD) epoch and the length of a sol in days
+	marsSolDateEpoch = 2405522.0028779
+	solDays          = 1.0274912517
+
+	// darianEpochMSD is the Mars Sol Date of sol 1 of Darian year 0, the
+	// northern vernal equinox of 1609 (the year of Kepler's laws of planetary
+	// motion)
+	darianEpochMSD = -94129
+	// darianQuarterSols is the length of a quarter of the Darian year (five
+	// months of 28 sols and one of 27)
+	darianQuarterSols = 167
+)
+
+// darianMonths are the months of the Darian calendar, which alternate between
+// the Latin and Sanskrit names of the constellations of the zodiac
+var darianMonths = [24]string{
+	"Sagittarius", "Dhanus", "Capricornus", "Makara", "Aquarius", "Kumbha",
+	"Pisces", "Mina", "Aries", "Mesha", "Taurus", "Rishabha",
+	"Gemini", "Mithuna", "Cancer", "Karka", "Leo", "Simha",
+	"Virgo", "Kanya", "Libra", "Tula", "Scorpius", "Vrishika",
+}
+
+// MarsLocation is a longitude on Mars for local mean solar time
+type MarsLocation struct {
+	Name      string  // Lander name, empty for a plain longitude
+	Longitude float64 // Degrees east of Airy-0 (planetocentric)
+}
+
+// marsLanders are the landing sites of the landers whose local time is
+// available by name
+var marsLanders = []MarsLocation{
+	{"Curiosity", 137.4417},   // Bradbury Landing, Gale crater
+	{"Perseverance", 77.4509}, // Octavia E. Butler Landing, Jezero crater
+	{"InSight", 135.6234},     // Elysium Planitia
+}
+
+// AllowedMarsLanders lists the lander names accepted by ParseMarsLocation
+var AllowedMarsLanders = func() []string {
+	names := make([]string, len(marsLanders))
+	for i, lander := range marsLanders {
+		names[i] = strings.ToLower(lander.Name)
+	}
+	return names
+}()
+
+// ParseMarsLocation parses a lander name (case insensitive) or a longitude in
+// degrees east. An empty name is the prime meridian, for which it returns nil.
+func ParseMarsLocation(name string) (*MarsLocation, error) {
+	if name == "" {
+		return nil, nil
+	}
+	for _, lander := range marsLanders {
+		if strings.EqualFold(lander.Name, name) {
+			return &lander, nil
+		}
+	}
+	longitude, err := strconv.ParseFloat(name, 64)
+	if err != nil || longitude < -180 || longitude > 360 {
+		return nil, fmt.Errorf("invalid Mars location '%s'. Allowed values: %s, or a longitude in degrees east", name, strings.Join(AllowedMarsLanders, ", "))
+	}
+	return &MarsLocation{Longitude: longitude}, nil
+}
+
+// longitude returns the longitude in degrees east, 0 for a nil location
+func (l *MarsLocation) longitude() float64 {
+	if l == nil {
+		return 0
+	}
+	return l.Longitude
+}
+
+// String returns the name of the lander with its longitude, or the longitude
+func (l *MarsLocation) String() string {
+	longitude := fmt.Sprintf("%.2f°E", astronomy.NormalizeDegrees(l.longitude()))
+	if l == nil || l.Name == "" {
+		return longitude
+	}
+	return fmt.Sprintf("%s (%s)", l.Name, longitude)
+}
+
+// marsSolDate returns the Mars Sol Date (MSD), the number of sols (Mars days)
+// since 1873-12-29. The epoch is a Julian Date in Terrestrial Time (TT), so t
+// is converted to TT with the embedded leap second table first.
+// See: https://www.giss.nasa.gov/tools/mars24/help/algorithm.html
+func marsSolDate(t time.Time) float64 {
+	tt := toTAI(t, nil).Add(ttMinusTAI)
+	return (astronomy.JulianDate(tt) - marsSolDateEpoch) / solDays
+}
+
+// localSolDate returns the Mars Sol Date at the location, whose fraction is the
+// local mean solar time
+func localSolDate(t time.Time, location *MarsLocation) float64 {
+	return marsSolDate(t) + location.longitude()/360
+}
+
+// formatMarsTime returns Coordinated Mars Time (MTC), the mean solar time at
+// the prime meridian, or the local mean solar time (LMST) at the location
+// See: https://en.wikipedia.org/wiki/Timekeeping_on_Mars
+func formatMarsTime(t time.Time, location *MarsLocation) string {
+	MSD := localSolDate(t, location)
+	mtc := 24.0 * (MSD - math.Floor(MSD))
+	hh := int(mtc)
+	mm := int((mtc - float64(hh)) * 60)
+	ss := int((((mtc - float64(hh)) * 60) - float64(mm)) * 60)
+	return fmt.Sprintf("%02d:%02d:%02d", hh, mm, ss)
+}
+
+// marsTimeZoneLabel returns the label of the mars format at the location
+func marsTimeZoneLabel(location *MarsLocation) string {
+	if location == nil {
+		return "Coordinated Mars Time"
+	}
+	return "Local Mean Solar Time @ " + location.String()
+}
+
+// isDarianLeapYear returns whether the Darian year has 669 sols instead of
+// 668: odd years and years divisible by 10, except years divisible by 100 but
+// not by 500
+func isDarianLeapYear(year int) bool {
+	if year%100 == 0 && year%500 != 0 {
+		return false
+	}
+	return year%2 != 0 || year%10 == 0
+}
+
+func darianYearSols(year int) int {
+	if isDarianLeapYear(year) {
+		return 669
+	}
+	return 668
+}
+
+// darianDate returns the Darian year, month (0-23) and sol of the month (1-28)
+// of the sol counted from the Darian epoch
+func darianDate(sols int) (year int, month int, sol int) {
+	for sols < 0 {
+		year--
+		sols += darianYearSols(year)
+	}
+	for sols >= darianYearSols(year) {
+		sols -= darianYearSols(year)
+		year++
+	}
+	// The last month of each quarter has 27 sols, except at the end of a leap
+	// year
+	quarter := min(sols/darianQuarterSols, 3)
+	sols -= quarter * darianQuarterSols
+	monthOfQuarter := min(sols/28, 5)
+	return year, quarter*6 + monthOfQuarter, sols - monthOfQuarter*28 + 1
+}
+
+// formatDarianDate returns the date of the Darian calendar for Mars at the
+// location (e.g. "13 Rishabha 214")
+// See: https://en.wikipedia.org/wiki/Darian_calendar
+func formatDarianDate(t time.Time, location *MarsLocation) string {
+	sols := int(math.Floor(localSolDate(t, location))) - darianEpochMSD
+	year, month, sol := darianDate(sols)
+	return fmt.Sprintf("%d %s %d", sol, darianMonths[month], year)
+}
@@ -0,0 +1,154 @@
+package display
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseMarsLocation(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected *MarsLocation
+	}{
+		{"", nil},
+		{"Curiosity", &MarsLocation{"Curiosity", 137.4417}},
+		{"INSIGHT", &MarsLocation{"InSight", 135.6234}},
+		{"perseverance", &MarsLocation{"Perseverance", 77.4509}},
+		{"-45.5", &MarsLocation{"", -45.5}},
+		{"354.5", &MarsLocation{"", 354.5}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMarsLocation(tt.name)
+		if err != nil {
+			t.Errorf("ParseMarsLocation(%q): unexpected error: %v", tt.name, err)
+			continue
+		}
+		if (got == nil) != (tt.expected == nil) || got != nil && *got != *tt.expected {
+			t.Errorf("ParseMarsLocation(%q): expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+
+	for _, name := range []string{"opportunity", "400", "-181", "137.4°E"} {
+		if _, err := ParseMarsLocation(name); err == nil || !strings.Contains(err.Error(), "curiosity, perseverance, insight") {
+			t.Errorf("ParseMarsLocation(%q): expected error listing the landers, got %v", name, err)
+		}
+	}
+}
+
+func TestFormatMarsTime_Location(t *testing.T) {
+	// Mars24 example A: MTC 23:59:39 on 2000-01-06 at 00:00 UTC
+	// See: https://www.giss.nasa.gov/tools/mars24/help/algorithm.html
+	inputTime := time.Date(2000, 1, 6, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		location *MarsLocation
+		expected string
+	}{
+		{nil, "23:59:39"},
+		{&MarsLocation{"", 0}, "23:59:39"},
+		{&MarsLocation{"Curiosity", 137.4417}, "09:09:25"},
+		{&MarsLocation{"", -45.5}, "20:57:39"},
+	}
+
+	for _, tt := range tests {
+		format := "mars"
+		if got := FormatTimeWithOptions(inputTime, &format, FormatOptions{MarsLocation: tt.location}); got != tt.expected {
+			t.Errorf("FormatTimeWithOptions(mars, %v): expected '%s', got '%s'", tt.location, tt.expected, got)
+		}
+	}
+}
+
+func TestIsDarianLeapYear(t *testing.T) {
+	tests := []struct {
+		year     int
+		expected bool
+	}{
+		{0, true},
+		{1, true},
+		{2, false},
+		{10, true},
+		{100, false},
+		{101, true},
+		{214, false},
+		{220, true},
+		{500, true},
+		{-1, true},
+	}
+
+	for _, tt := range tests {
+		if got := isDarianLeapYear(tt.year); got != tt.expected {
+			t.Errorf("isDarianLeapYear(%d): expected %v, got %v", tt.year, tt.expected, got)
+		}
+	}
+}
+
+func TestDarianDate(t *testing.T) {
+	tests := []struct {
+		sols  int
+		year  int
+		month int
+		sol   int
+	}{
+		{0, 0, 0, 1},
+		{27, 0, 0, 28},
+		{28, 0, 1, 1},
+		{166, 0, 5, 27},
+		{167, 0, 6, 1},
+		{667, 0, 23, 27},
+		// Year 0 is a leap year with 28 sols in the last month
+		{668, 0, 23, 28},
+		{669, 1, 0, 1},
+		{1338, 2, 0, 1},
+		// Year 2 has 668 sols
+		{2005, 2, 23, 27},
+		{2006, 3, 0, 1},
+		{-1, -1, 23, 28},
+	}
+
+	for _, tt := range tests {
+		if year, month, sol := darianDate(tt.sols); year != tt.year || month != tt.month || sol != tt.sol {
+			t.Errorf("darianDate(%d): expected %d-%d-%d, got %d-%d-%d", tt.sols, tt.year, tt.month, tt.sol, year, month, sol)
+		}
+	}
+}
+
+func TestFormatDarianDate(t *testing.T) {
+	tests := []struct {
+		t        time.Time
+		location *MarsLocation
+		expected string
+	}{
+		// The northern vernal equinox starting Mars Year 1 (1955-04-11)
+		{time.Date(1955, 4, 11, 0, 0, 0, 0, time.UTC), nil, "28 Vrishika 183"},
+		{time.Date(1955, 4, 12, 12, 0, 0, 0, time.UTC), nil, "1 Sagittarius 184"},
+		// The landing of Curiosity
+		{time.Date(2012, 8, 6, 5, 17, 0, 0, time.UTC), nil, "13 Rishabha 214"},
+		{time.Date(2023, 10, 1, 15, 16, 17, 0, time.UTC), nil, "22 Mesha 220"},
+		// It is already the next sol at Gale crater
+		{time.Date(2023, 10, 1, 15, 16, 17, 0, time.UTC), &MarsLocation{"Curiosity", 137.4417}, "23 Mesha 220"},
+	}
+
+	for _, tt := range tests {
+		if got := formatDarianDate(tt.t, tt.location); got != tt.expected {
+			t.Errorf("formatDarianDate(%v, %v): expected '%s', got '%s'", tt.t, tt.location, tt.expected, got)
+		}
+	}
+}
+
+func TestMarsTimeZoneLabel(t *testing.T) {
+	tests := []struct {
+		location *MarsLocation
+		expected string
+	}{
+		{nil, "Coordinated Mars Time"},
+		{&MarsLocation{"Perseverance", 77.4509}, "Local Mean Solar Time @ Perseverance (77.45°E)"},
+		{&MarsLocation{"", -45.5}, "Local Mean Solar Time @ 314.50°E"},
+	}
+
+	for _, tt := range tests {
+		if got := marsTimeZoneLabel(tt.location); got != tt.expected {
+			t.Errorf("marsTimeZoneLabel(%v): expected '%s', got '%s'", tt.location, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package display
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// natoZones are the letters and names of the NATO (military) time zones by
+// their offset from UTC in hours. J (Juliett) is not a zone, it stands for the
+// observer's local time.
+// See: https://en.wikipedia.org/wiki/Military_time_zone
+var natoZones = map[int]struct{ letter, name string }{
+	0:   {"Z", "Zulu"},
+	1:   {"A", "Alpha"},
+	2:   {"B", "Bravo"},
+	3:   {"C", "Charlie"},
+	4:   {"D", "Delta"},
+	5:   {"E", "Echo"},
+	6:   {"F", "Foxtrot"},
+	7:   {"G", "Golf"},
+	8:   {"H", "Hotel"},
+	9:   {"I", "India"},
+	10:  {"K", "Kilo"},
+	11:  {"L", "Lima"},
+	12:  {"M", "Mike"},
+	-1:  {"N", "November"},
+	-2:  {"O", "Oscar"},
+	-3:  {"P", "Papa"},
+	-4:  {"Q", "Quebec"},
+	-5:  {"R", "Romeo"},
+	-6:  {"S", "Sierra"},
+	-7:  {"T", "Tango"},
+	-8:  {"U", "Uniform"},
+	-9:  {"V", "Victor"},
+	-10: {"W", "Whiskey"},
+	-11: {"X", "X-ray"},
+	-12: {"Y", "Yankee"},
+}
+
+// natoZone returns the letter and name of the NATO time zone of the offset of
+// t in its location (e.g. "B" and "Bravo" for CEST). Offsets that are not whole
+// hours (e.g. India, Nepal, Newfoundland) or beyond ±12 hours (e.g. Samoa,
+// Kiribati) have no zone letter.
+func natoZone(t time.Time) (letter string, name string, ok bool) {
+	_, offset := t.Zone()
+	if offset%3600 != 0 {
+		return "", "", false
+	}
+	zone, ok := natoZones[offset/3600]
+	return zone.letter, zone.name, ok
+}
+
+// formatDTG returns the military date-time group of t, with the day, hours and
+// minutes, the NATO zone letter, the month and the year (e.g. "181430ZOCT26"
+// for 14:30 UTC on 18 October 2026). Without a zone letter for the offset, the
+// group is given in UTC (Z), so it stays unambiguous.
+func formatDTG(t time.Time) string {
+	letter, _, ok := natoZone(t)
+	if !ok {
+		t = t.UTC()
+		letter = "Z"
+	}
+	return t.Format("021504") + letter + strings.ToUpper(t.Format("Jan06"))
+}
+
+// militaryTimeZoneLabel returns the NATO time zone of the dtg format (e.g.
+// "Bravo Time (UTC+02:00)"), and why it is Zulu for offsets without a zone
+// letter
+func militaryTimeZoneLabel(t time.Time) string {
+	_, name, ok := natoZone(t)
+	if !ok {
+		return fmt.Sprintf("Zulu Time (%s has no zone letter)", formatUTCOffset(t))
+	}
+	return fmt.Sprintf("%s Time (%s)", name, formatUTCOffset(t))
+}
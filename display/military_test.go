@@ -0,0 +1,84 @@
+package display
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNATOZone(t *testing.T) {
+	instant := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		location string
+		letter   string
+		ok       bool
+	}{
+		{"UTC", "Z", true},
+		{"Europe/London", "Z", true},
+		{"Europe/Berlin", "A", true},
+		{"Asia/Tokyo", "I", true},
+		// J is skipped
+		{"Australia/Brisbane", "K", true},
+		{"Pacific/Auckland", "", false}, // UTC+13 in the southern summer
+		{"Pacific/Kiritimati", "", false},
+		{"America/New_York", "R", true},
+		{"Pacific/Pago_Pago", "X", true},
+		{"Etc/GMT+12", "Y", true},
+		// Offsets that are not whole hours
+		{"Asia/Kolkata", "", false},
+		{"Asia/Kathmandu", "", false},
+		{"America/St_Johns", "", false},
+		{"Australia/Eucla", "", false},
+	}
+
+	for _, tt := range tests {
+		letter, _, ok := natoZone(instant.In(mustLoadLocation(tt.location)))
+		if letter != tt.letter || ok != tt.ok {
+			t.Errorf("natoZone(%s): expected '%s' %v, got '%s' %v", tt.location, tt.letter, tt.ok, letter, ok)
+		}
+	}
+}
+
+func TestFormatDTG(t *testing.T) {
+	instant := time.Date(2026, 10, 18, 14, 30, 45, 0, time.UTC)
+	tests := []struct {
+		location string
+		expected string
+	}{
+		{"UTC", "181430ZOCT26"},
+		// Daylight saving time changes the zone
+		{"Europe/Berlin", "181630BOCT26"},
+		{"America/Los_Angeles", "180730TOCT26"},
+		// The local date of the zone
+		{"Pacific/Honolulu", "180430WOCT26"},
+		{"Asia/Tokyo", "182330IOCT26"},
+		{"Australia/Sydney", "190130LOCT26"},
+		// Zones without a letter are given in UTC
+		{"Asia/Kolkata", "181430ZOCT26"},
+		{"Pacific/Chatham", "181430ZOCT26"},
+	}
+
+	for _, tt := range tests {
+		if got := formatDTG(instant.In(mustLoadLocation(tt.location))); got != tt.expected {
+			t.Errorf("formatDTG(%s): expected '%s', got '%s'", tt.location, tt.expected, got)
+		}
+	}
+}
+
+func TestMilitaryTimeZoneLabel(t *testing.T) {
+	instant := time.Date(2026, 10, 18, 14, 30, 0, 0, time.UTC)
+	tests := []struct {
+		location string
+		expected string
+	}{
+		{"UTC", "Zulu Time (UTC+00:00)"},
+		{"Europe/Berlin", "Bravo Time (UTC+02:00)"},
+		{"America/New_York", "Quebec Time (UTC-04:00)"},
+		{"Asia/Kolkata", "Zulu Time (UTC+05:30 has no zone letter)"},
+	}
+
+	for _, tt := range tests {
+		if got := militaryTimeZoneLabel(instant.In(mustLoadLocation(tt.location))); got != tt.expected {
+			t.Errorf("militaryTimeZoneLabel(%s): expected '%s', got '%s'", tt.location, tt.expected, got)
+		}
+	}
+}
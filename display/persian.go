@@ -0,0 +1,75 @@
+package display
+
+import (
+	"fmt"
+	"time"
+
+	"chrono-ntp/locale"
+)
+
+// persianCalendar is the Solar Hijri calendar of Iran and Afghanistan. The
+// year starts at the March equinox (Nowruz), with six months of 31 days, five
+// of 30 and one of 29 or 30.
+// See: https://en.wikipedia.org/wiki/Solar_Hijri_calendar
+type persianCalendar struct{}
+
+var persianMonths = [12]string{
+	"Farvardin", "Ordibehesht", "Khordad", "Tir", "Mordad", "Shahrivar",
+	"Mehr", "Aban", "Azar", "Dey", "Bahman", "Esfand",
+}
+
+// persianReferenceYear starts on a known Nowruz, from which the other years
+// are counted
+const persianReferenceYear = 1400
+
+var persianReferenceNewYear = fixedDay(time.Date(2021, 3, 21, 0, 0, 0, 0, time.UTC))
+
+func (persianCalendar) Name() string {
+	return "persian"
+}
+
+func (persianCalendar) FormatDate(t time.Time, _ *locale.Locale) string {
+	year, month, day := persianFromFixed(fixedDay(t))
+	return fmt.Sprintf("%d %s %d SH", day, persianMonths[month-1], year)
+}
+
+// isPersianLeapYear returns whether the year has 366 days, with the 33-year
+// cycle of 8 leap years. The official calendar follows the time of the
+// equinox in Tehran, which the cycle matches from 1178 to 1634 SH (1799 to
+// 2256).
+func isPersianLeapYear(year int) bool {
+	return floorMod(25*year+11, 33) < 8
+}
+
+func persianYearDays(year int) int {
+	if isPersianLeapYear(year) {
+		return 366
+	}
+	return 365
+}
+
+// persianNewYear returns the fixed day of 1 Farvardin of the year
+func persianNewYear(year int) int {
+	day := persianReferenceNewYear
+	for y := persianReferenceYear; y < year; y++ {
+		day += persianYearDays(y)
+	}
+	for y := year; y < persianReferenceYear; y++ {
+		day -= persianYearDays(y)
+	}
+	return day
+}
+
+func persianFromFixed(day int) (year int, month int, dayOfMonth int) {
+	// The year starts on 20 or 21 March, 621 years after the Gregorian year
+	year = timeOfFixedDay(day, time.UTC).Year() - 621
+	if day < persianNewYear(year) {
+		year--
+	}
+	dayOfYear := day - persianNewYear(year)
+	if dayOfYear < 6*31 {
+		return year, dayOfYear/31 + 1, dayOfYear%31 + 1
+	}
+	dayOfYear -= 6 * 31
+	return year, dayOfYear/30 + 7, dayOfYear%30 + 1
+}
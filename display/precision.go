@@ -0,0 +1,67 @@
+package display
+
+import (
+	"slices"
+	"time"
+)
+
+// MaxTimePrecision is the maximum number of fractional second digits
+// (milliseconds)
+const MaxTimePrecision = 3
+
+// tickEdgeDuration is how long the tick edge indicator is shown after each
+// second boundary. It divides a second evenly, so redraws aligned to it
+// switch the indicator on and off exactly.
+const tickEdgeDuration = 125 * time.Millisecond
+
+// redrawIntervals maps a time precision to the interval between redraws,
+// which are aligned to the boundaries of the interval. Every step of the
+// precision is shown, but redrawing a terminal faster than every 10 ms is not
+// useful.
+var redrawIntervals = [...]time.Duration{
+	time.Second,
+	100 * time.Millisecond,
+	10 * time.Millisecond,
+	10 * time.Millisecond,
+}
+
+// RedrawPrecision returns the number of fractional second digits the display
+// needs to be redrawn at to show every change of the time format
+func RedrawPrecision(timeFormat string, precision int, dayCountPrecision int) int {
+	if slices.Contains(dayCountFormats, timeFormat) {
+		return dayCountRedrawPrecision(dayCountPrecision)
+	}
+	if isSubSecondEpochFormat(timeFormat) {
+		return MaxTimePrecision
+	}
+	return max(precision, CustomFormatPrecision(timeFormat))
+}
+
+// RedrawInterval returns the interval between redraws for the given number of
+// fractional second digits and whether the tick edge indicator is shown
+func RedrawInterval(precision int, tickIndicator bool) time.Duration {
+	interval := redrawIntervals[max(min(precision, MaxTimePrecision), 0)]
+	if tickIndicator {
+		interval = min(interval, tickEdgeDuration)
+	}
+	return interval
+}
+
+// RedrawIntervalFor returns the interval between redraws for the time format,
+// with the time precision, the number of decimals of day counts and whether
+// the tick edge indicator is shown. The redraws are aligned to midnight in the
+// time zone.
+func RedrawIntervalFor(timeFormat string, precision int, dayCountPrecision int, tickIndicator bool) time.Duration {
+	switch timeFormat {
+	case "decimal":
+		return decimalRedrawInterval(precision, tickIndicator)
+	case "gmst", "gast", "lst":
+		return siderealRedrawInterval(precision, tickIndicator)
+	}
+	return RedrawInterval(RedrawPrecision(timeFormat, precision, dayCountPrecision), tickIndicator)
+}
+
+// IsTickEdge reports whether t is just after a second boundary
+func IsTickEdge(t time.Time) bool {
+	return time.Duration(t.Nanosecond()) < tickEdgeDuration
+}
@@ -0,0 +1,48 @@
+package display
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRedrawInterval(t *testing.T) {
+	tests := []struct {
+		precision     int
+		tickIndicator bool
+		expected      time.Duration
+	}{
+		{-1, false, time.Second},
+		{0, false, time.Second},
+		{0, true, 125 * time.Millisecond},
+		{1, false, 100 * time.Millisecond},
+		{1, true, 100 * time.Millisecond},
+		{2, false, 10 * time.Millisecond},
+		{3, false, 10 * time.Millisecond},
+		{4, false, 10 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		if got := RedrawInterval(tt.precision, tt.tickIndicator); got != tt.expected {
+			t.Errorf("RedrawInterval(%d, %v): expected %v, got %v", tt.precision, tt.tickIndicator, tt.expected, got)
+		}
+	}
+}
+
+func TestIsTickEdge(t *testing.T) {
+	tests := []struct {
+		nanosecond int
+		expected   bool
+	}{
+		{0, true},
+		{124_999_999, true},
+		{125_000_000, false},
+		{999_999_999, false},
+	}
+
+	for _, tt := range tests {
+		tm := time.Date(2025, 11, 11, 12, 0, 0, tt.nanosecond, time.UTC)
+		if got := IsTickEdge(tm); got != tt.expected {
+			t.Errorf("IsTickEdge(%d ns): expected %v, got %v", tt.nanosecond, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package display
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"chrono-ntp/astronomy"
+	"chrono-ntp/locale"
+)
+
+// republicanCalendar is the French Republican calendar, used in France from
+// 1793 to 1805. The year starts on the day of the September equinox in Paris,
+// with twelve months of 30 days and five or six complementary days.
+// See: https://en.wikipedia.org/wiki/French_Republican_calendar
+type republicanCalendar struct{}
+
+var republicanMonths = [12]string{
+	"Vendémiaire", "Brumaire", "Frimaire", "Nivôse", "Pluviôse", "Ventôse",
+	"Germinal", "Floréal", "Prairial", "Messidor", "Thermidor", "Fructidor",
+}
+
+// republicanComplementaryDays are the days after Fructidor (sansculottides),
+// the last one only in leap years
+var republicanComplementaryDays = [6]string{
+	"Jour de la vertu", "Jour du génie", "Jour du travail",
+	"Jour de l'opinion", "Jour des récompenses", "Jour de la Révolution",
+}
+
+// parisMeanTime is the time of the Paris Observatory, which decided the day
+// of the equinox
+var parisMeanTime = time.FixedZone("PMT", 9*60+21)
+
+// republicanEpoch is the fixed day of 1 Vendémiaire An I (1792-09-22)
+var republicanEpoch = fixedDay(time.Date(1792, 9, 22, 0, 0, 0, 0, time.UTC))
+
+func (republicanCalendar) Name() string {
+	return "republican"
+}
+
+// FormatDate returns the day, month and year in Roman numerals (e.g. "18
+// Brumaire An VIII"). Dates before the calendar's epoch are shown as ISO 8601
+// dates.
+func (republicanCalendar) FormatDate(t time.Time, _ *locale.Locale) string {
+	day := fixedDay(t)
+	if day < republicanEpoch {
+		return t.Format("2006-01-02")
+	}
+	year, month, dayOfMonth := republicanFromFixed(day)
+	if month == 13 {
+		return fmt.Sprintf("%s An %s", republicanComplementaryDays[dayOfMonth-1], romanNumeral(year))
+	}
+	return fmt.Sprintf("%d %s An %s", dayOfMonth, republicanMonths[month-1], romanNumeral(year))
+}
+
+// republicanNewYear returns the fixed day of 1 Vendémiaire of the year
+func republicanNewYear(year int) int {
+	near := time.Date(year+1791, 9, 22, 12, 0, 0, 0, time.UTC)
+	return fixedDay(astronomy.SolarLongitudeTime(180, near).In(parisMeanTime))
+}
+
+// republicanFromFixed converts a fixed day to a date of the Republican
+// calendar, with month 13 for the complementary days
+func republicanFromFixed(day int) (year int, month int, dayOfMonth int) {
+	gregorianYear, _, _ := timeOfFixedDay(day, time.UTC).Date()
+	year = gregorianYear - 1791
+	newYear := republicanNewYear(year)
+	if day < newYear {
+		year--
+		newYear = republicanNewYear(year)
+	}
+	dayOfYear := day - newYear
+	return year, dayOfYear/30 + 1, dayOfYear%30 + 1
+}
+
+var romanNumerals = []struct {
+	value   int
+	numeral string
+}{
+	{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"}, {100, "C"}, {90, "XC"},
+	{50, "L"}, {40, "XL"}, {10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"},
+}
+
+// romanNumeral returns n (at least 1) in Roman numerals
+func romanNumeral(n int) string {
+	var b strings.Builder
+	for _, r := range romanNumerals {
+		for n >= r.value {
+			b.WriteString(r.numeral)
+			n -= r.value
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,193 @@
+package display
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"chrono-ntp/clock"
+	"chrono-ntp/locale"
+)
+
+// CustomFormatPrefix starts a date or time format with a strftime pattern
+// (e.g. "custom:%A, %d %B %Y")
+const CustomFormatPrefix = "custom:"
+
+const maxStrftimePatternLength = 64
+
+// strftimeDirectives lists the supported conversions (see README.md)
+const strftimeDirectives = "aAbhBCdeFGHIjmMNpRsSTuVwyYzZ%"
+
+type strftimeToken struct {
+	literal   string
+	directive byte // Zero for literal text
+	digits    int  // Digits of %N, zero for the default of 9
+}
+
+// CustomFormatPattern returns the strftime pattern of a custom format
+func CustomFormatPattern(format string) (string, bool) {
+	return strings.CutPrefix(format, CustomFormatPrefix)
+}
+
+// ValidateStrftime checks that a strftime pattern only contains supported
+// directives and no control characters
+func ValidateStrftime(pattern string) error {
+	_, err := parseStrftime(pattern)
+	return err
+}
+
+func parseStrftime(pattern string) ([]strftimeToken, error) {
+	if pattern == "" {
+		return nil, fmt.Errorf("empty pattern")
+	}
+	if utf8.RuneCountInString(pattern) > maxStrftimePatternLength {
+		return nil, fmt.Errorf("pattern longer than %d characters", maxStrftimePatternLength)
+	}
+	if strings.ContainsFunc(pattern, unicode.IsControl) {
+		return nil, fmt.Errorf("pattern contains control characters")
+	}
+
+	tokens := []strftimeToken{}
+	for pattern != "" {
+		i := strings.IndexByte(pattern, '%')
+		if i < 0 {
+			tokens = append(tokens, strftimeToken{literal: pattern})
+			break
+		}
+		if i > 0 {
+			tokens = append(tokens, strftimeToken{literal: pattern[:i]})
+		}
+		pattern = pattern[i+1:]
+
+		token := strftimeToken{}
+		if pattern != "" && pattern[0] >= '1' && pattern[0] <= '9' {
+			token.digits = int(pattern[0] - '0')
+			pattern = pattern[1:]
+		}
+		if pattern == "" {
+			return nil, fmt.Errorf("pattern ends with an incomplete directive")
+		}
+		token.directive = pattern[0]
+		if strings.IndexByte(strftimeDirectives, token.directive) < 0 {
+			r, _ := utf8.DecodeRuneInString(pattern)
+			return nil, fmt.Errorf("unknown directive '%%%c'", r)
+		}
+		if token.digits > 0 && token.directive != 'N' {
+			return nil, fmt.Errorf("digits are only allowed for %%N, not '%%%d%c'", token.digits, token.directive)
+		}
+		pattern = pattern[1:]
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
+// CustomFormatPrecision returns the number of fractional second digits shown
+// by a custom format (up to MaxTimePrecision), so the display can be redrawn
+// often enough. It is zero for all other formats.
+func CustomFormatPrecision(format string) int {
+	pattern, ok := CustomFormatPattern(format)
+	if !ok {
+		return 0
+	}
+	tokens, _ := parseStrftime(pattern)
+	precision := 0
+	for _, token := range tokens {
+		if token.directive == 'N' {
+			digits := token.digits
+			if digits == 0 {
+				digits = 9
+			}
+			precision = max(precision, min(digits, MaxTimePrecision))
+		}
+	}
+	return precision
+}
+
+// formatStrftime formats t with a strftime pattern, using the names of the
+// locale. Invalid patterns are shown as is.
+func formatStrftime(t time.Time, pattern string, l *locale.Locale) string {
+	tokens, err := parseStrftime(pattern)
+	if err != nil {
+		return pattern
+	}
+
+	var b strings.Builder
+	for _, token := range tokens {
+		if token.directive == 0 {
+			b.WriteString(token.literal)
+			continue
+		}
+		b.WriteString(formatStrftimeDirective(t, token, l))
+	}
+	return b.String()
+}
+
+func formatStrftimeDirective(t time.Time, token strftimeToken, l *locale.Locale) string {
+	switch token.directive {
+	case 'a':
+		return l.AbbreviatedWeekday(t.Weekday())
+	case 'A':
+		return l.Weekday(t.Weekday())
+	case 'b', 'h':
+		return l.AbbreviatedMonth(t.Month())
+	case 'B':
+		return l.Month(t.Month())
+	case 'C':
+		return fmt.Sprintf("%02d", t.Year()/100)
+	case 'd':
+		return t.Format("02")
+	case 'e':
+		return t.Format("_2")
+	case 'F':
+		return t.Format("2006-01-02")
+	case 'G':
+		year, _ := t.ISOWeek()
+		return strconv.Itoa(year)
+	case 'H':
+		return t.Format("15")
+	case 'I':
+		return t.Format("03")
+	case 'j':
+		return t.Format("002")
+	case 'm':
+		return t.Format("01")
+	case 'M':
+		return t.Format("04")
+	case 'N':
+		digits := token.digits
+		if digits == 0 {
+			digits = 9
+		}
+		return fmt.Sprintf("%09d", t.Nanosecond())[:digits]
+	case 'p':
+		return l.Meridiem(t)
+	case 'R':
+		return t.Format("15:04")
+	case 's':
+		return strconv.FormatInt(t.Unix(), 10)
+	case 'S':
+		return t.Format("05")
+	case 'T':
+		return t.Format("15:04:05")
+	case 'u':
+		return strconv.Itoa(clock.ISOWeekday(t))
+	case 'V':
+		_, week := t.ISOWeek()
+		return fmt.Sprintf("%02d", week)
+	case 'w':
+		return strconv.Itoa(int(t.Weekday()))
+	case 'y':
+		return t.Format("06")
+	case 'Y':
+		return strconv.Itoa(t.Year())
+	case 'z':
+		return t.Format("-0700")
+	case 'Z':
+		return t.Format("MST")
+	default: // %%
+		return "%"
+	}
+}
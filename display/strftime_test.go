@@ -0,0 +1,148 @@
+package display
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"chrono-ntp/locale"
+)
+
+func TestFormatStrftime(t *testing.T) {
+	inputTime := time.Date(2023, 10, 3, 15, 16, 17, 987654321, time.UTC)
+	tests := []struct {
+		pattern  string
+		expected string
+	}{
+		{"%A, %d %B %Y", "Tuesday, 03 October 2023"},
+		{"%H:%M:%S.%3N", "15:16:17.987"},
+		{"%a %b %e", "Tue Oct  3"},
+		{"%h", "Oct"},
+		{"%I:%M %p", "03:16 PM"},
+		{"%F %T", "2023-10-03 15:16:17"},
+		{"%R", "15:16"},
+		{"%N", "987654321"},
+		{"%1N", "9"},
+		{"%s", "1696346177"},
+		{"%C %y", "20 23"},
+		{"%m/%d", "10/03"},
+		{"%j", "276"},
+		{"%V", "40"},
+		{"%G-W%V-%u", "2023-W40-2"},
+		{"%w", "2"},
+		{"%z %Z", "+0000 UTC"},
+		{"100%%", "100%"},
+		{"no directives", "no directives"},
+		{"⌚ %H", "⌚ 15"},
+	}
+
+	for _, tt := range tests {
+		if got := formatStrftime(inputTime, tt.pattern, nil); got != tt.expected {
+			t.Errorf("formatStrftime(%q): expected '%s', got '%s'", tt.pattern, tt.expected, got)
+		}
+	}
+}
+
+func TestFormatStrftime_ISOWeekYear(t *testing.T) {
+	// January 1st, 2021 is a Friday in the last ISO week of 2020
+	inputTime := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got := formatStrftime(inputTime, "%G-W%V-%u %j", nil); got != "2020-W53-5 001" {
+		t.Errorf("expected '2020-W53-5 001', got '%s'", got)
+	}
+}
+
+func TestFormatStrftime_TimeZone(t *testing.T) {
+	inputTime := time.Date(2023, 10, 3, 15, 16, 17, 0, mustLoadLocation("America/New_York"))
+	if got := formatStrftime(inputTime, "%H:%M %Z (%z)", nil); got != "15:16 EDT (-0400)" {
+		t.Errorf("expected '15:16 EDT (-0400)', got '%s'", got)
+	}
+}
+
+func TestFormatStrftime_Localized(t *testing.T) {
+	inputTime := time.Date(2023, 10, 3, 15, 16, 17, 0, time.UTC)
+	tests := []struct {
+		locale   string
+		pattern  string
+		expected string
+	}{
+		{"de-DE", "%A, %e. %B %Y", "Dienstag,  3. Oktober 2023"},
+		{"fr-FR", "%a %d %b", "mar. 03 oct."},
+		{"ja-JP", "%p %I時%M分", "午後 03時16分"},
+		{"es-ES", "%A %d de %B", "martes 03 de octubre"},
+	}
+
+	for _, tt := range tests {
+		l, _ := locale.Lookup(tt.locale)
+		if got := formatStrftime(inputTime, tt.pattern, l); got != tt.expected {
+			t.Errorf("formatStrftime(%q, %q): expected '%s', got '%s'", tt.pattern, tt.locale, tt.expected, got)
+		}
+	}
+}
+
+func TestValidateStrftime(t *testing.T) {
+	tests := []struct {
+		pattern string
+		err     string
+	}{
+		{"%A, %d %B %Y", ""},
+		{"%H:%M:%S.%3N", ""},
+		{"%%", ""},
+		{"", "empty pattern"},
+		{"%", "pattern ends with an incomplete directive"},
+		{"%H:%3", "pattern ends with an incomplete directive"},
+		{"%Q", "unknown directive '%Q'"},
+		{"%é", "unknown directive '%é'"},
+		{"%3H", "digits are only allowed for %N, not '%3H'"},
+		{"%H\n%M", "pattern contains control characters"},
+		{"\x1b[31m%H", "pattern contains control characters"},
+		{strings.Repeat("x", 65), "pattern longer than 64 characters"},
+	}
+
+	for _, tt := range tests {
+		err := ValidateStrftime(tt.pattern)
+		switch {
+		case tt.err == "" && err != nil:
+			t.Errorf("ValidateStrftime(%q): expected no error, got '%v'", tt.pattern, err)
+		case tt.err != "" && (err == nil || err.Error() != tt.err):
+			t.Errorf("ValidateStrftime(%q): expected error '%s', got '%v'", tt.pattern, tt.err, err)
+		}
+	}
+}
+
+func TestFormatCustom(t *testing.T) {
+	inputTime := time.Date(2023, 10, 3, 15, 16, 17, 987654321, time.UTC)
+	dateFormat := "custom:%A, %d %B %Y"
+	if got := FormatDate(inputTime, &dateFormat); got != "Tuesday, 03 October 2023" {
+		t.Errorf("FormatDate(%q): expected 'Tuesday, 03 October 2023', got '%s'", dateFormat, got)
+	}
+
+	// The precision does not apply to custom formats
+	timeFormat := "custom:%T"
+	if got := FormatTimeWithOptions(inputTime, &timeFormat, FormatOptions{Precision: 3}); got != "15:16:17" {
+		t.Errorf("FormatTimeWithOptions(%q): expected '15:16:17', got '%s'", timeFormat, got)
+	}
+
+	invalidFormat := "custom:%Q"
+	if got := FormatTime(inputTime, &invalidFormat); got != "%Q" {
+		t.Errorf("FormatTime(%q): expected '%%Q', got '%s'", invalidFormat, got)
+	}
+}
+
+func TestCustomFormatPrecision(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected int
+	}{
+		{"custom:%H:%M:%S.%3N", 3},
+		{"custom:%N", 3},
+		{"custom:%1N %2N", 2},
+		{"custom:%T", 0},
+		{"ISO8601", 0},
+	}
+
+	for _, tt := range tests {
+		if got := CustomFormatPrecision(tt.format); got != tt.expected {
+			t.Errorf("CustomFormatPrecision(%q): expected %d, got %d", tt.format, tt.expected, got)
+		}
+	}
+}
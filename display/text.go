@@ -0,0 +1,41 @@
+package display
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
+)
+
+// textWidth returns the number of terminal cells needed to draw the text
+func textWidth(text string) int {
+	width := 0
+	graphemes := uniseg.NewGraphemes(text)
+	for graphemes.Next() {
+		width += graphemeWidth(graphemes.Runes())
+	}
+	return width
+}
+
+// graphemeWidth returns the number of cells of a grapheme cluster. Like tcell,
+// it is determined by the first rune; zero width runes still take one cell.
+func graphemeWidth(runes []rune) int {
+	return max(runewidth.RuneWidth(runes[0]), 1)
+}
+
+// drawText draws the text starting at x, putting each grapheme cluster (a base
+// character with its combining characters) into one cell, or two cells if it
+// is wide (e.g. CJK). It returns the x position after the text.
+func drawText(s tcell.Screen, x int, y int, text string, style tcell.Style) int {
+	graphemes := uniseg.NewGraphemes(text)
+	for graphemes.Next() {
+		runes := graphemes.Runes()
+		s.SetContent(x, y, runes[0], runes[1:], style)
+		x += graphemeWidth(runes)
+	}
+	return x
+}
+
+func drawTextCentered(s tcell.Screen, y int, text string, style tcell.Style) {
+	w, _ := s.Size()
+	drawText(s, (w-textWidth(text))/2, y, text, style)
+}
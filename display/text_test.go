@@ -0,0 +1,117 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// screenText returns the text of a screen row as a terminal shows it: with
+// combining characters and without the cells covered by wide characters
+func screenText(screen tcell.SimulationScreen, y int) string {
+	width, _ := screen.Size()
+	text := []rune{}
+	for x := 0; x < width; {
+		r, combining, _, w := screen.GetContent(x, y)
+		text = append(text, r)
+		text = append(text, combining...)
+		x += max(w, 1)
+	}
+	return string(text)
+}
+
+func newTextScreen(t *testing.T, width int) tcell.SimulationScreen {
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("failed to initialize screen: %v", err)
+	}
+	t.Cleanup(screen.Fini)
+	screen.SetSize(width, 1)
+	return screen
+}
+
+func TestTextWidth(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected int
+	}{
+		{"", 0},
+		{"Europe/Berlin", 13},
+		{"São Paulo", 9},
+		{"São Paulo", 9}, // Decomposed ã
+		{"東京", 4},
+		{"2023年10月3日", 13},
+		{"서울 KST", 8},
+	}
+
+	for _, test := range tests {
+		if got := textWidth(test.text); got != test.expected {
+			t.Errorf("textWidth(%q): expected %d, got %d", test.text, test.expected, got)
+		}
+	}
+}
+
+func TestDrawText(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+		end      int
+	}{
+		{"Berlin", "Berlin    ", 6},
+		{"São", "São       ", 3},
+		{"東京 JST", "東京 JST  ", 8},
+	}
+
+	for _, test := range tests {
+		screen := newTextScreen(t, 10)
+		end := drawText(screen, 0, 0, test.text, tcell.StyleDefault)
+		if end != test.end {
+			t.Errorf("drawText(%q): expected end %d, got %d", test.text, test.end, end)
+		}
+		if got := screenText(screen, 0); got != test.expected {
+			t.Errorf("drawText(%q): expected '%s', got '%s'", test.text, test.expected, got)
+		}
+	}
+}
+
+func TestDrawTextWide(t *testing.T) {
+	screen := newTextScreen(t, 6)
+	drawText(screen, 1, 0, "東京", tcell.StyleDefault)
+
+	for x, expected := range map[int]rune{1: '東', 3: '京'} {
+		if r, _, _, width := screen.GetContent(x, 0); r != expected || width != 2 {
+			t.Errorf("cell %d: expected %q of width 2, got %q of width %d", x, expected, r, width)
+		}
+	}
+}
+
+func TestDrawTextCentered(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"UTC", "    UTC    "},
+		{"Zürich", "  Zürich   "},
+		{"Zürich", "  Zürich   "},
+		{"東京", "   東京    "},
+	}
+
+	for _, test := range tests {
+		screen := newTextScreen(t, 11)
+		drawTextCentered(screen, 0, test.text, tcell.StyleDefault)
+		if got := screenText(screen, 0); got != test.expected {
+			t.Errorf("drawTextCentered(%q): expected '%s', got '%s'", test.text, test.expected, got)
+		}
+	}
+}
+
+func TestDrawShortcutWide(t *testing.T) {
+	screen := newTextScreen(t, 20)
+	x := drawShortcut(screen, defaultTheme, 0, 0, "終", "終了", tcell.StyleDefault)
+	if x != 11 {
+		t.Errorf("expected next shortcut at 11, got %d", x)
+	}
+	if got, expected := screenText(screen, 0), "終 終了             "; got != expected {
+		t.Errorf("expected '%s', got '%s'", expected, got)
+	}
+}
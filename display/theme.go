@@ -0,0 +1,174 @@
+package display
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+type Element string
+
+const (
+	ElementBackground     Element = "background"
+	ElementTime           Element = "time"
+	ElementDate           Element = "date"
+	ElementTimeZone       Element = "time-zone"
+	ElementStatusBarKey   Element = "status-bar-key"
+	ElementStatusBarLabel Element = "status-bar-label"
+	ElementHelp           Element = "help"
+	ElementGraph          Element = "graph"
+	ElementCalendar       Element = "calendar"
+	ElementAstronomy      Element = "astronomy"
+)
+
+var AllowedThemes = [...]string{"default", "dark", "light", "solarized", "high-contrast", "amber"}
+
+// ElementColors holds color names (e.g. "red") or hex values (e.g. "#ff0000")
+// for an element. Empty values keep the color of the theme.
+type ElementColors struct {
+	Foreground string
+	Background string
+}
+
+type Theme map[Element]tcell.Style
+
+var defaultTheme = Theme{
+	ElementBackground:     tcell.StyleDefault,
+	ElementTime:           tcell.StyleDefault.Bold(true),
+	ElementDate:           tcell.StyleDefault,
+	ElementTimeZone:       tcell.StyleDefault,
+	ElementStatusBarKey:   tcell.StyleDefault.Bold(true).Reverse(true),
+	ElementStatusBarLabel: tcell.StyleDefault,
+	ElementHelp:           tcell.StyleDefault.Reverse(true),
+	ElementGraph:          tcell.StyleDefault,
+	ElementCalendar:       tcell.StyleDefault,
+	ElementAstronomy:      tcell.StyleDefault,
+}
+
+var builtinThemes = map[string]Theme{
+	"default": defaultTheme,
+	"dark": newColorTheme(
+		"#000000", "#e0e0e0", "#ffffff", "#9e9e9e", "#303030", "#bdbdbd",
+	),
+	"light": newColorTheme(
+		"#fafafa", "#424242", "#000000", "#616161", "#e0e0e0", "#424242",
+	),
+	// See: https://ethanschoonover.com/solarized/
+	"solarized": newColorTheme(
+		"#002b36", "#839496", "#93a1a1", "#2aa198", "#073642", "#268bd2",
+	),
+	"high-contrast": newColorTheme(
+		"#000000", "#ffffff", "#ffff00", "#00ffff", "#ffffff", "#ffffff",
+	),
+	// Emulates the phosphor of an amber monochrome CRT monitor
+	"amber": newColorTheme(
+		"#1a0f00", "#cc7a00", "#ffb000", "#995c00", "#3d2600", "#ffb000",
+	),
+}
+
+// newColorTheme creates a theme from a background color, a default text color,
+// a color for the time, a color for the time zone, and the background and text
+// colors of highlighted elements (status bar keys, help overlay)
+func newColorTheme(background string, text string, time string, timeZone string, highlightBackground string, highlightText string) Theme {
+	base := tcell.StyleDefault.Background(tcell.GetColor(background)).Foreground(tcell.GetColor(text))
+	highlight := base.Background(tcell.GetColor(highlightBackground)).Foreground(tcell.GetColor(highlightText))
+	return Theme{
+		ElementBackground:     base,
+		ElementTime:           base.Foreground(tcell.GetColor(time)).Bold(true),
+		ElementDate:           base,
+		ElementTimeZone:       base.Foreground(tcell.GetColor(timeZone)),
+		ElementStatusBarKey:   highlight.Bold(true),
+		ElementStatusBarLabel: base,
+		ElementHelp:           highlight,
+		ElementGraph:          base.Foreground(tcell.GetColor(timeZone)),
+		ElementCalendar:       base,
+		ElementAstronomy:      base.Foreground(tcell.GetColor(timeZone)),
+	}
+}
+
+// NewTheme returns the built-in theme with the given name, with the colors of
+// individual elements overridden
+func NewTheme(name string, overrides map[string]ElementColors) (Theme, error) {
+	builtin, ok := builtinThemes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown theme '%s'", name)
+	}
+
+	theme := Theme{}
+	for element, style := range builtin {
+		theme[element] = style
+	}
+
+	for name, colors := range overrides {
+		element := Element(name)
+		style, ok := theme[element]
+		if !ok {
+			return nil, fmt.Errorf("unknown element '%s'", name)
+		}
+		if colors.Foreground != "" {
+			color, err := parseColor(colors.Foreground)
+			if err != nil {
+				return nil, err
+			}
+			style = style.Foreground(color)
+		}
+		if colors.Background != "" {
+			color, err := parseColor(colors.Background)
+			if err != nil {
+				return nil, err
+			}
+			style = style.Background(color)
+		}
+		theme[element] = style
+	}
+	return theme, nil
+}
+
+func parseColor(name string) (tcell.Color, error) {
+	if name == "default" {
+		return tcell.ColorDefault, nil
+	}
+	color := tcell.GetColor(name)
+	if color == tcell.ColorDefault {
+		return color, fmt.Errorf("invalid color '%s'", name)
+	}
+	return color, nil
+}
+
+// ForColors returns the theme with RGB colors mapped to the closest color of
+// the palette if the terminal supports fewer colors than truecolor (e.g. 256
+// or 16 colors)
+func (t Theme) ForColors(colors int) Theme {
+	if colors >= 1<<24 {
+		return t
+	}
+
+	palette := make([]tcell.Color, min(colors, 256))
+	for i := range palette {
+		palette[i] = tcell.PaletteColor(i)
+	}
+
+	adapted := Theme{}
+	for element, style := range t {
+		fg, bg, attr := style.Decompose()
+		adapted[element] = tcell.StyleDefault.
+			Foreground(fitColor(fg, palette)).
+			Background(fitColor(bg, palette)).
+			Attributes(attr)
+	}
+	return adapted
+}
+
+func fitColor(color tcell.Color, palette []tcell.Color) tcell.Color {
+	if !color.IsRGB() || len(palette) == 0 {
+		return color
+	}
+	return tcell.FindColor(color, palette)
+}
+
+func (t Theme) style(element Element) tcell.Style {
+	if style, ok := t[element]; ok {
+		return style
+	}
+	return tcell.StyleDefault
+}
@@ -0,0 +1,94 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestNewTheme_Builtin(t *testing.T) {
+	for _, name := range AllowedThemes {
+		theme, err := NewTheme(name, nil)
+		if err != nil {
+			t.Fatalf("NewTheme(%q): unexpected error: %v", name, err)
+		}
+		if len(theme) != len(defaultTheme) {
+			t.Errorf("NewTheme(%q): expected %d elements, got %d", name, len(defaultTheme), len(theme))
+		}
+	}
+}
+
+func TestNewTheme_UnknownTheme(t *testing.T) {
+	if _, err := NewTheme("neon", nil); err == nil {
+		t.Errorf("expected error for unknown theme")
+	}
+}
+
+func TestNewTheme_Overrides(t *testing.T) {
+	theme, err := NewTheme("solarized", map[string]ElementColors{
+		"time": {Foreground: "#ff0000"},
+		"date": {Foreground: "yellow", Background: "default"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fg, bg, attr := theme.style(ElementTime).Decompose()
+	if fg != tcell.NewHexColor(0xff0000) {
+		t.Errorf("expected time foreground #ff0000, got %v", fg)
+	}
+	if bg != tcell.NewHexColor(0x002b36) {
+		t.Errorf("expected time background to be kept, got %v", bg)
+	}
+	if attr&tcell.AttrBold == 0 {
+		t.Errorf("expected time to stay bold")
+	}
+
+	fg, bg, _ = theme.style(ElementDate).Decompose()
+	if fg != tcell.ColorYellow || bg != tcell.ColorDefault {
+		t.Errorf("expected date yellow on default, got %v on %v", fg, bg)
+	}
+
+	// The built-in theme must not be modified
+	if fg, _, _ := builtinThemes["solarized"].style(ElementTime).Decompose(); fg != tcell.NewHexColor(0x93a1a1) {
+		t.Errorf("expected built-in theme to be unchanged, got %v", fg)
+	}
+}
+
+func TestNewTheme_OverrideErrors(t *testing.T) {
+	tests := []map[string]ElementColors{
+		{"clock": {Foreground: "red"}},
+		{"time": {Foreground: "not-a-color"}},
+		{"time": {Background: "#12345"}},
+	}
+
+	for _, overrides := range tests {
+		if _, err := NewTheme("default", overrides); err == nil {
+			t.Errorf("NewTheme(%v): expected error", overrides)
+		}
+	}
+}
+
+func TestTheme_ForColors(t *testing.T) {
+	theme, _ := NewTheme("amber", nil)
+
+	if truecolor := theme.ForColors(1 << 24); truecolor.style(ElementTime) != theme.style(ElementTime) {
+		t.Errorf("expected truecolor theme to be unchanged")
+	}
+
+	tests := []int{256, 16}
+	for _, colors := range tests {
+		adapted := theme.ForColors(colors)
+		for element, style := range adapted {
+			fg, bg, _ := style.Decompose()
+			for _, color := range []tcell.Color{fg, bg} {
+				if color.IsRGB() || (color != tcell.ColorDefault && int(color-tcell.ColorValid) >= colors) {
+					t.Errorf("ForColors(%d): %s has color %v outside of palette", colors, element, color)
+				}
+			}
+		}
+		if _, _, attr := adapted.style(ElementTime).Decompose(); attr&tcell.AttrBold == 0 {
+			t.Errorf("ForColors(%d): expected attributes to be kept", colors)
+		}
+	}
+}
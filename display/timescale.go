@@ -0,0 +1,57 @@
+package display
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"chrono-ntp/leapseconds"
+)
+
+const (
+	// Offsets of the time scales from TAI
+	gpsMinusTAI   = -19 * time.Second
+	loranMinusTAI = -10 * time.Second
+	ttMinusTAI    = 32184 * time.Millisecond
+
+	gpsWeek = 7 * 24 * time.Hour
+)
+
+// gpsEpoch is the start of GPS week 0
+var gpsEpoch = time.Date(1980, 1, 6, 0, 0, 0, 0, time.UTC)
+
+// timeScaleFormats lists the time formats that depend on the leap second table
+var timeScaleFormats = []string{"tai", "gps", "loran", "tt"}
+
+// UsesLeapSeconds returns whether the time format depends on the leap second
+// table
+func UsesLeapSeconds(timeFormat string) bool {
+	return slices.Contains(timeScaleFormats, timeFormat)
+}
+
+// toTAI returns the TAI reading of the UTC time t, as a time.Time (which has
+// no leap seconds itself)
+func toTAI(t time.Time, table *leapseconds.Table) time.Time {
+	return t.UTC().Add(time.Duration(table.TAIMinusUTC(t)) * time.Second)
+}
+
+// formatTimeScale returns the time of day in International Atomic Time (TAI),
+// LORAN-C time or Terrestrial Time (TT), or the GPS week number and time of
+// week. Unlike UTC, these scales have no leap seconds.
+// See: https://en.wikipedia.org/wiki/International_Atomic_Time
+func formatTimeScale(t time.Time, timeFormat string, fraction string, table *leapseconds.Table) string {
+	tai := toTAI(t, table)
+	switch timeFormat {
+	case "gps":
+		// GPS time has been TAI-19s since its epoch, which was TAI-UTC=19s
+		elapsed := tai.Add(gpsMinusTAI).Sub(gpsEpoch)
+		return fmt.Sprintf("WN %d TOW %d", elapsed/gpsWeek, (elapsed%gpsWeek)/time.Second) + tai.Format(fraction)
+	case "loran":
+		// LORAN-C time has been TAI-10s since its epoch (1958-01-01)
+		return tai.Add(loranMinusTAI).Format("15:04:05" + fraction)
+	case "tt":
+		return tai.Add(ttMinusTAI).Format("15:04:05" + fraction)
+	default:
+		return tai.Format("15:04:05" + fraction)
+	}
+}
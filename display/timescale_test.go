@@ -0,0 +1,96 @@
+package display
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"chrono-ntp/leapseconds"
+)
+
+func TestFormatTimeScale(t *testing.T) {
+	// The leap second at the end of 2016 is 2016-12-31T23:59:60 UTC
+	beforeLeap := time.Date(2016, 12, 31, 23, 59, 59, 0, time.UTC)
+	afterLeap := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		format    string
+		t         time.Time
+		precision int
+		expected  string
+	}{
+		{"tai", beforeLeap, 0, "00:00:35"},
+		{"tai", afterLeap, 0, "00:00:37"},
+		{"tai", time.Date(2009, 1, 1, 0, 0, 0, 0, time.UTC), 0, "00:00:34"},
+		{"tai", time.Date(2008, 12, 31, 23, 59, 59, 500000000, time.UTC), 1, "00:00:32.5"},
+		{"gps", beforeLeap, 0, "WN 1930 TOW 16"},
+		{"gps", afterLeap, 0, "WN 1930 TOW 18"},
+		{"gps", afterLeap.Add(123 * time.Millisecond), 3, "WN 1930 TOW 18.123"},
+		// GPS week 2048 (the second 10-bit week number rollover)
+		{"gps", time.Date(2019, 4, 6, 23, 59, 41, 0, time.UTC), 0, "WN 2047 TOW 604799"},
+		{"gps", time.Date(2019, 4, 6, 23, 59, 42, 0, time.UTC), 0, "WN 2048 TOW 0"},
+		{"gps", time.Date(1980, 1, 6, 0, 0, 0, 0, time.UTC), 0, "WN 0 TOW 0"},
+		{"loran", beforeLeap, 0, "00:00:25"},
+		{"loran", afterLeap, 0, "00:00:27"},
+		{"tt", beforeLeap, 0, "00:01:07"},
+		{"tt", afterLeap, 3, "00:01:09.184"},
+		// Time scales are independent of the time zone
+		{"tai", afterLeap.In(time.FixedZone("CET", 3600)), 0, "00:00:37"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatTimeWithOptions(tt.t, &tt.format, FormatOptions{Precision: tt.precision}); got != tt.expected {
+			t.Errorf("FormatTimeWithOptions(%v, %q, %d): expected '%s', got '%s'", tt.t, tt.format, tt.precision, tt.expected, got)
+		}
+	}
+}
+
+func TestFormatTimeScale_Table(t *testing.T) {
+	// A table with a (hypothetical) leap second at the end of 2030
+	table, err := leapseconds.Parse(strings.NewReader("#@\t4102444800\n3692217600\t37\n4133980800\t38\n"))
+	if err != nil {
+		t.Fatalf("failed to parse table: %v", err)
+	}
+	format := "tai"
+	afterLeap := time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got := FormatTimeWithOptions(afterLeap, &format, FormatOptions{LeapSeconds: table}); got != "00:00:38" {
+		t.Errorf("expected '00:00:38', got '%s'", got)
+	}
+	if got := FormatTimeWithOptions(afterLeap, &format, FormatOptions{}); got != "00:00:37" {
+		t.Errorf("expected '00:00:37' with the embedded table, got '%s'", got)
+	}
+}
+
+func TestUsesLeapSeconds(t *testing.T) {
+	for _, format := range AllowedTimeFormats {
+		expected := format == "tai" || format == "gps" || format == "loran" || format == "tt"
+		if got := UsesLeapSeconds(format); got != expected {
+			t.Errorf("UsesLeapSeconds(%q): expected %v, got %v", format, expected, got)
+		}
+	}
+}
+
+func TestDrawStatusBar_LeapSecondsExpired(t *testing.T) {
+	table, err := leapseconds.Parse(strings.NewReader("#@\t3692217600\n3692217600\t37\n"))
+	if err != nil {
+		t.Fatalf("failed to parse table: %v", err)
+	}
+	screen := newTextScreen(t, 120)
+	state := DisplayState{
+		Now:         time.Date(2017, 1, 2, 0, 0, 0, 0, time.UTC),
+		TimeFormat:  "tai",
+		Offline:     true,
+		LeapSeconds: table,
+	}
+
+	drawStatusBar(screen, state, defaultKeyBindings, defaultTheme)
+	if row := screenText(screen, 0); !strings.Contains(row, "Leap Seconds expired 2017-01-01") {
+		t.Errorf("expected leap second warning, got '%s'", row)
+	}
+
+	screen.Clear()
+	state.TimeFormat = "ISO8601"
+	drawStatusBar(screen, state, defaultKeyBindings, defaultTheme)
+	if row := screenText(screen, 0); strings.Contains(row, "Leap Seconds") {
+		t.Errorf("expected no leap second warning for ISO8601, got '%s'", row)
+	}
+}
@@ -0,0 +1,94 @@
+package display
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxZoneScans limits the zone periods scanned for the next DST transition.
+// Some zones change their abbreviation without changing the offset, but no
+// zone does so more than a few times between two DST transitions.
+const maxZoneScans = 8
+
+// timeZoneLabel returns the name of the location of t with its abbreviation,
+// UTC offset and a countdown to the next DST transition (e.g. "Europe/Berlin
+// (CEST, UTC+02:00, DST ends in 6d 14h)"). A change of the standard offset,
+// when neither side of it is DST, is counted down as an offset change.
+func timeZoneLabel(t time.Time) string {
+	details := []string{}
+	if abbreviation, _ := t.Zone(); abbreviation != t.Location().String() && !isNumericAbbreviation(abbreviation) {
+		details = append(details, abbreviation)
+	}
+	offset := formatUTCOffset(t)
+	if transition, ok := nextOffsetChange(t); ok {
+		countdown := formatCountdown(transition.Sub(t))
+		switch {
+		case !t.IsDST() && !transition.IsDST():
+			offset += ", offset changes in " + countdown
+		case isDST(t):
+			offset += ", DST ends in " + countdown
+		default:
+			offset += ", DST starts in " + countdown
+		}
+	}
+	details = append(details, offset)
+	return fmt.Sprintf("%s (%s)", normalizeTimeZoneName(t.Location()), strings.Join(details, ", "))
+}
+
+// formatUTCOffset returns the offset of t in its location (e.g. "UTC+05:30")
+func formatUTCOffset(t time.Time) string {
+	return "UTC" + t.Format("-07:00")
+}
+
+// isNumericAbbreviation reports whether the zone abbreviation is only the
+// offset (e.g. "+01" or "-0330"), which zones without a customary
+// abbreviation use
+func isNumericAbbreviation(abbreviation string) bool {
+	return strings.HasPrefix(abbreviation, "+") || strings.HasPrefix(abbreviation, "-")
+}
+
+// nextOffsetChange returns the next time after t when the UTC offset of its
+// location changes, scanning the periods of the zone rules. Zones without
+// future changes (e.g. Asia/Tokyo) have none.
+func nextOffsetChange(t time.Time) (time.Time, bool) {
+	_, offset := t.Zone()
+	for range maxZoneScans {
+		_, end := t.ZoneBounds()
+		if end.IsZero() {
+			return time.Time{}, false
+		}
+		if _, endOffset := end.Zone(); endOffset != offset {
+			return end, true
+		}
+		t = end
+	}
+	return time.Time{}, false
+}
+
+// isDST reports whether daylight saving time is in effect at t, which is when
+// the clocks are ahead of the offset they change to next. The tz database
+// marks Irish winter time and the Ramadan time of Morocco as DST (negative
+// DST), which time.Time.IsDST reports, but they set the clocks back.
+func isDST(t time.Time) bool {
+	next, ok := nextOffsetChange(t)
+	if !ok {
+		return false
+	}
+	_, offset := t.Zone()
+	_, nextOffset := next.Zone()
+	return offset > nextOffset
+}
+
+// formatCountdown returns a duration in its two largest units (e.g. "6d 14h",
+// "3h 05m" or "4m 30s"), truncated
+func formatCountdown(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd %dh", d/(24*time.Hour), d%(24*time.Hour)/time.Hour)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh %02dm", d/time.Hour, d%time.Hour/time.Minute)
+	default:
+		return fmt.Sprintf("%dm %02ds", d/time.Minute, d%time.Minute/time.Second)
+	}
+}
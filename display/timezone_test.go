@@ -0,0 +1,93 @@
+package display
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeZoneLabel(t *testing.T) {
+	january := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		location string
+		t        time.Time
+		expected string
+	}{
+		{"Europe/Berlin", january, "Europe/Berlin (CET, UTC+01:00, DST starts in 72d 13h)"},
+		{"Europe/Berlin", time.Date(2026, 10, 18, 10, 30, 0, 0, time.UTC), "Europe/Berlin (CEST, UTC+02:00, DST ends in 6d 14h)"},
+		{"America/New_York", time.Date(2026, 3, 8, 6, 15, 0, 0, time.UTC), "America/New York (EST, UTC-05:00, DST starts in 45m 00s)"},
+		// DST of 30 minutes
+		{"Australia/Lord_Howe", january, "Australia/Lord Howe (UTC+11:00, DST ends in 79d 3h)"},
+		{"Australia/Lord_Howe", time.Date(2026, 4, 4, 14, 0, 0, 0, time.UTC), "Australia/Lord Howe (UTC+11:00, DST ends in 1h 00m)"},
+		// Offsets of 45 minutes
+		{"Pacific/Chatham", january, "Pacific/Chatham (UTC+13:45, DST ends in 79d 2h)"},
+		// The clocks are set back during Ramadan. The dates are predicted, so
+		// past ones are used.
+		{"Africa/Casablanca", time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC), "Africa/Casablanca (UTC+01:00, DST ends in 54d 14h)"},
+		{"Africa/Casablanca", time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC), "Africa/Casablanca (UTC+00:00, DST starts in 24d 14h)"},
+		// Irish winter time is negative DST in the tz database
+		{"Europe/Dublin", january, "Europe/Dublin (GMT, UTC+00:00, DST starts in 72d 13h)"},
+		{"Europe/Dublin", time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC), "Europe/Dublin (IST, UTC+01:00, DST ends in 115d 13h)"},
+		// Changes of the standard offset
+		{"Europe/Volgograd", time.Date(2020, 12, 1, 12, 0, 0, 0, time.UTC), "Europe/Volgograd (UTC+04:00, offset changes in 25d 10h)"},
+		{"Asia/Pyongyang", time.Date(2018, 4, 1, 12, 0, 0, 0, time.UTC), "Asia/Pyongyang (KST, UTC+08:30, offset changes in 33d 3h)"},
+		// No DST
+		{"Asia/Tokyo", january, "Asia/Tokyo (JST, UTC+09:00)"},
+		{"Asia/Kolkata", january, "Asia/Kolkata (IST, UTC+05:30)"},
+		{"America/Sao_Paulo", january, "America/Sao Paulo (UTC-03:00)"},
+		{"UTC", january, "UTC (UTC+00:00)"},
+	}
+
+	for _, tt := range tests {
+		if got := timeZoneLabel(tt.t.In(mustLoadLocation(tt.location))); got != tt.expected {
+			t.Errorf("timeZoneLabel(%s, %v): expected '%s', got '%s'", tt.location, tt.t, tt.expected, got)
+		}
+	}
+}
+
+func TestIsDST(t *testing.T) {
+	tests := []struct {
+		location string
+		t        time.Time
+		expected bool
+	}{
+		{"Europe/Berlin", time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC), false},
+		{"Europe/Berlin", time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC), true},
+		// Southern hemisphere
+		{"Australia/Lord_Howe", time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC), true},
+		{"Australia/Lord_Howe", time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC), false},
+		{"Pacific/Chatham", time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC), false},
+		// Negative DST, which time.Time.IsDST reports the other way around
+		{"Europe/Dublin", time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC), false},
+		{"Europe/Dublin", time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC), true},
+		{"Africa/Casablanca", time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC), false},
+		{"Africa/Casablanca", time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC), true},
+		{"Asia/Tokyo", time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		if got := isDST(tt.t.In(mustLoadLocation(tt.location))); got != tt.expected {
+			t.Errorf("isDST(%s, %v): expected %v, got %v", tt.location, tt.t, tt.expected, got)
+		}
+	}
+}
+
+func TestFormatCountdown(t *testing.T) {
+	tests := []struct {
+		d        time.Duration
+		expected string
+	}{
+		{0, "0m 00s"},
+		{90 * time.Second, "1m 30s"},
+		{time.Hour - time.Nanosecond, "59m 59s"},
+		{time.Hour, "1h 00m"},
+		{23*time.Hour + 59*time.Minute + 59*time.Second, "23h 59m"},
+		{24 * time.Hour, "1d 0h"},
+		{200*24*time.Hour + 5*time.Hour + 30*time.Minute, "200d 5h"},
+	}
+
+	for _, tt := range tests {
+		if got := formatCountdown(tt.d); got != tt.expected {
+			t.Errorf("formatCountdown(%v): expected '%s', got '%s'", tt.d, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package holidays
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Holidays holds the all-day events of an iCalendar file, by date. Events with
+// a yearly recurrence rule repeat on the same month and day every year.
+// See: https://datatracker.ietf.org/doc/html/rfc5545
+type Holidays struct {
+	dates  map[string]string // YYYYMMDD to summary
+	yearly map[string]string // MMDD to summary
+}
+
+func Load(path string) (*Holidays, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Parse(file)
+}
+
+// Parse reads the events of an iCalendar file. Only the start date, summary
+// and whether an event recurs yearly are used.
+func Parse(r io.Reader) (*Holidays, error) {
+	h := &Holidays{dates: map[string]string{}, yearly: map[string]string{}}
+
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var date, summary string
+	var yearly bool
+	for _, line := range lines {
+		name, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		// Property parameters (e.g. DTSTART;VALUE=DATE) are not needed
+		name, _, _ = strings.Cut(strings.ToUpper(name), ";")
+
+		switch name {
+		case "BEGIN":
+			if value == "VEVENT" {
+				date, summary, yearly = "", "", false
+			}
+		case "DTSTART":
+			if len(value) >= 8 {
+				date = value[:8]
+			}
+		case "SUMMARY":
+			summary = unescapeText(value)
+		case "RRULE":
+			yearly = strings.Contains(strings.ToUpper(value), "FREQ=YEARLY")
+		case "END":
+			if value != "VEVENT" || date == "" {
+				continue
+			}
+			if yearly {
+				h.yearly[date[4:]] = summary
+			} else {
+				h.dates[date] = summary
+			}
+		}
+	}
+	return h, nil
+}
+
+// Lookup returns the summary of the holiday on the date of t
+func (h *Holidays) Lookup(t time.Time) (string, bool) {
+	if h == nil {
+		return "", false
+	}
+	date := t.Format("20060102")
+	if summary, ok := h.dates[date]; ok {
+		return summary, true
+	}
+	summary, ok := h.yearly[date[4:]]
+	return summary, ok
+}
+
+// unfoldLines returns the content lines, joining lines which were folded
+// (continuation lines start with a space or tab)
+func unfoldLines(r io.Reader) ([]string, error) {
+	lines := []string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+func unescapeText(text string) string {
+	return strings.NewReplacer(`\,`, ",", `\;`, ";", `\n`, " ", `\N`, " ", `\\`, `\`).Replace(text)
+}
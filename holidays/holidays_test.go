@@ -0,0 +1,85 @@
+package holidays
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testCalendar = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20231003\r\n" +
+	"SUMMARY:Tag der Deutschen\r\n" +
+	"  Einheit\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20001225\r\n" +
+	"RRULE:FREQ=YEARLY\r\n" +
+	"SUMMARY:Christmas Day\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART:20240401T000000Z\r\n" +
+	"SUMMARY:Easter Monday\\, observed\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:No start date\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParse(t *testing.T) {
+	h, err := Parse(strings.NewReader(testCalendar))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		date     time.Time
+		expected string
+		found    bool
+	}{
+		{time.Date(2023, 10, 3, 12, 0, 0, 0, time.UTC), "Tag der Deutschen Einheit", true},
+		{time.Date(2024, 10, 3, 12, 0, 0, 0, time.UTC), "", false},
+		{time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC), "Christmas Day", true},
+		{time.Date(2031, 12, 25, 23, 59, 0, 0, time.UTC), "Christmas Day", true},
+		{time.Date(2024, 4, 1, 8, 0, 0, 0, time.UTC), "Easter Monday, observed", true},
+		{time.Date(2024, 4, 2, 8, 0, 0, 0, time.UTC), "", false},
+	}
+
+	for _, tt := range tests {
+		summary, found := h.Lookup(tt.date)
+		if summary != tt.expected || found != tt.found {
+			t.Errorf("Lookup(%v): expected %q, %v, got %q, %v", tt.date, tt.expected, tt.found, summary, found)
+		}
+	}
+}
+
+func TestLookup_Nil(t *testing.T) {
+	var h *Holidays
+	if _, found := h.Lookup(time.Now()); found {
+		t.Errorf("expected no holiday")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "holidays.ics")
+	if err := os.WriteFile(path, []byte(testCalendar), 0644); err != nil {
+		t.Fatalf("Failed to write calendar: %v", err)
+	}
+
+	h, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, found := h.Lookup(time.Date(2023, 10, 3, 0, 0, 0, 0, time.UTC)); !found {
+		t.Errorf("expected holiday to be loaded")
+	}
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.ics")); err == nil {
+		t.Errorf("expected error")
+	}
+}
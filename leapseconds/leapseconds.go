@@ -0,0 +1,162 @@
+package leapseconds
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ntpEpochOffset is the number of seconds from the NTP epoch (1900-01-01) to
+// the Unix epoch (1970-01-01)
+const ntpEpochOffset = 2208988800
+
+// initialTAIMinusUTC is TAI-UTC before the first entry of the table. Before
+// 1972, UTC seconds were not SI seconds, so this is only an approximation.
+const initialTAIMinusUTC = 10
+
+// The list of leap seconds published by the IERS, as distributed with the IANA
+// time zone database
+// See: https://hpiers.obspm.fr/iers/bul/bulc/ntp/leap-seconds.list
+//
+//go:embed leap-seconds.list
+var embeddedList []byte
+
+var embedded = sync.OnceValue(func() *Table {
+	table, err := Parse(bytes.NewReader(embeddedList))
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded leap second list: %v", err))
+	}
+	return table
+})
+
+type entry struct {
+	start       time.Time // UTC
+	taiMinusUTC int
+}
+
+// Table holds the difference between TAI and UTC since 1972, which changes
+// with each leap second
+type Table struct {
+	entries []entry
+	updated time.Time
+	expires time.Time // The IERS may announce leap seconds after this date
+}
+
+// Embedded returns the table compiled into the program
+func Embedded() *Table {
+	return embedded()
+}
+
+func Load(path string) (*Table, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Parse(file)
+}
+
+// Parse reads a leap second list in the format of the IERS and IETF (NTP
+// timestamps with TAI-UTC, "#$" for the last update and "#@" for the
+// expiration date)
+func Parse(r io.Reader) (*Table, error) {
+	table := &Table{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "#$"):
+			updated, err := parseNTPTimestamp(strings.TrimPrefix(line, "#$"))
+			if err != nil {
+				return nil, fmt.Errorf("invalid update date: %w", err)
+			}
+			table.updated = updated
+		case strings.HasPrefix(line, "#@"):
+			expires, err := parseNTPTimestamp(strings.TrimPrefix(line, "#@"))
+			if err != nil {
+				return nil, fmt.Errorf("invalid expiration date: %w", err)
+			}
+			table.expires = expires
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		default:
+			fields := strings.Fields(line)
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("invalid line '%s'", line)
+			}
+			start, err := parseNTPTimestamp(fields[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid line '%s': %w", line, err)
+			}
+			taiMinusUTC, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid line '%s': %w", line, err)
+			}
+			if len(table.entries) > 0 && !start.After(table.entries[len(table.entries)-1].start) {
+				return nil, fmt.Errorf("invalid line '%s': entries are not in order", line)
+			}
+			table.entries = append(table.entries, entry{start, taiMinusUTC})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(table.entries) == 0 {
+		return nil, fmt.Errorf("no leap seconds found")
+	}
+	if table.expires.IsZero() {
+		return nil, fmt.Errorf("no expiration date found")
+	}
+	return table, nil
+}
+
+func parseNTPTimestamp(s string) (time.Time, error) {
+	seconds, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(seconds-ntpEpochOffset, 0).UTC(), nil
+}
+
+func (t *Table) orEmbedded() *Table {
+	if t == nil {
+		return Embedded()
+	}
+	return t
+}
+
+// TAIMinusUTC returns the difference between TAI and UTC in seconds at the
+// given time. A nil table is the embedded table.
+func (t *Table) TAIMinusUTC(utc time.Time) int {
+	taiMinusUTC := initialTAIMinusUTC
+	for _, e := range t.orEmbedded().entries {
+		if utc.Before(e.start) {
+			break
+		}
+		taiMinusUTC = e.taiMinusUTC
+	}
+	return taiMinusUTC
+}
+
+// Updated returns the date of the last update of the table
+func (t *Table) Updated() time.Time {
+	return t.orEmbedded().updated
+}
+
+// Expires returns the date after which the table may miss newly announced leap
+// seconds
+func (t *Table) Expires() time.Time {
+	return t.orEmbedded().expires
+}
+
+// Expired returns whether the table is past its expiration date
+func (t *Table) Expired(now time.Time) bool {
+	return !now.Before(t.Expires())
+}
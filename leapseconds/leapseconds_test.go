@@ -0,0 +1,87 @@
+package leapseconds
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEmbedded(t *testing.T) {
+	table := Embedded()
+	if len(table.entries) != 28 {
+		t.Errorf("expected 28 entries, got %d", len(table.entries))
+	}
+	if expected := time.Date(2026, 6, 28, 0, 0, 0, 0, time.UTC); !table.Expires().Equal(expected) {
+		t.Errorf("expected expiration %v, got %v", expected, table.Expires())
+	}
+	if expected := time.Date(2025, 7, 7, 0, 0, 0, 0, time.UTC); !table.Updated().Equal(expected) {
+		t.Errorf("expected update %v, got %v", expected, table.Updated())
+	}
+}
+
+func TestTAIMinusUTC(t *testing.T) {
+	tests := []struct {
+		utc      time.Time
+		expected int
+	}{
+		{time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), 10},
+		{time.Date(1972, 1, 1, 0, 0, 0, 0, time.UTC), 10},
+		{time.Date(1972, 6, 30, 23, 59, 59, 0, time.UTC), 10},
+		{time.Date(1972, 7, 1, 0, 0, 0, 0, time.UTC), 11},
+		{time.Date(1980, 1, 6, 0, 0, 0, 0, time.UTC), 19},
+		{time.Date(2008, 12, 31, 23, 59, 59, 999999999, time.UTC), 33},
+		{time.Date(2009, 1, 1, 0, 0, 0, 0, time.UTC), 34},
+		{time.Date(2016, 12, 31, 23, 59, 59, 0, time.UTC), 36},
+		{time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), 37},
+		// The offset is independent of the time zone
+		{time.Date(2016, 12, 31, 19, 0, 0, 0, time.FixedZone("EST", -5*3600)), 37},
+		{time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), 37},
+	}
+
+	var table *Table // The embedded table
+	for _, tt := range tests {
+		if got := table.TAIMinusUTC(tt.utc); got != tt.expected {
+			t.Errorf("TAIMinusUTC(%v): expected %d, got %d", tt.utc, tt.expected, got)
+		}
+	}
+}
+
+func TestExpired(t *testing.T) {
+	table, err := Parse(strings.NewReader("#@\t3692217600\n3692217600\t37\n"))
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	if table.Expired(time.Date(2016, 12, 31, 23, 59, 59, 0, time.UTC)) {
+		t.Errorf("expected table not to be expired before the expiration date")
+	}
+	if !table.Expired(time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected table to be expired at the expiration date")
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		content string
+		err     string
+	}{
+		{"#@\t3692217600\n", "no leap seconds found"},
+		{"3692217600\t37\n", "no expiration date found"},
+		{"#@\tsoon\n3692217600\t37\n", "invalid expiration date"},
+		{"#@\t3692217600\n3692217600\n", "invalid line '3692217600'"},
+		{"#@\t3692217600\n3692217600\tmany\n", "invalid line '3692217600\tmany'"},
+		{"#@\t3692217600\n3692217600\t37\n3644697600\t36\n", "entries are not in order"},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(strings.NewReader(tt.content))
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("Parse(%q): expected error containing '%s', got '%v'", tt.content, tt.err, err)
+		}
+	}
+}
+
+func TestLoad_NotFound(t *testing.T) {
+	if _, err := Load("/nonexistent/leap-seconds.list"); err == nil {
+		t.Errorf("expected error for missing file")
+	}
+}
@@ -0,0 +1,124 @@
+package locale
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Locale holds the names and patterns needed to show dates and times in a
+// language. Patterns contain the placeholders {weekday}, {day}, {month} and
+// {year}, which are replaced with the localized names and numbers.
+type Locale struct {
+	Name                string
+	Weekdays            [7]string // By time.Weekday, starting on Sunday
+	AbbreviatedWeekdays [7]string
+	ShortWeekdays       [7]string  // At most two cells wide, for the calendar
+	Months              [12]string // January to December
+	AbbreviatedMonths   [12]string
+	AM                  string
+	PM                  string
+	MeridiemFirst       bool   // Whether the AM/PM marker precedes the time
+	LongDatePattern     string // Full date, including the weekday
+	MonthYearPattern    string // Calendar title
+	ShortDateLayout     string // Numeric date as a Go time layout (e.g. "02.01.2006")
+	// Translations of user interface labels, by their English text. Missing
+	// labels are shown in English.
+	Labels map[string]string
+}
+
+// AllowedLocales lists the bundled locales
+var AllowedLocales = [...]string{"en-US", "en-GB", "de-DE", "fr-FR", "es-ES", "it-IT", "ja-JP", "zh-CN"}
+
+// Default is the locale used when none is given (US English)
+var Default = locales["en-US"]
+
+// Lookup returns the bundled locale with the given name (e.g. "de-DE")
+func Lookup(name string) (*Locale, bool) {
+	l, ok := locales[name]
+	return l, ok
+}
+
+func (l *Locale) orDefault() *Locale {
+	if l == nil {
+		return Default
+	}
+	return l
+}
+
+// Weekday returns the localized name of the weekday
+func (l *Locale) Weekday(weekday time.Weekday) string {
+	return l.orDefault().Weekdays[weekday]
+}
+
+// ShortWeekday returns the localized abbreviation of the weekday
+func (l *Locale) ShortWeekday(weekday time.Weekday) string {
+	return l.orDefault().ShortWeekdays[weekday]
+}
+
+// AbbreviatedWeekday returns the localized abbreviation of the weekday (e.g.
+// "Mon")
+func (l *Locale) AbbreviatedWeekday(weekday time.Weekday) string {
+	return l.orDefault().AbbreviatedWeekdays[weekday]
+}
+
+// Month returns the localized name of the month
+func (l *Locale) Month(month time.Month) string {
+	return l.orDefault().Months[month-1]
+}
+
+// AbbreviatedMonth returns the localized abbreviation of the month (e.g.
+// "Jan")
+func (l *Locale) AbbreviatedMonth(month time.Month) string {
+	return l.orDefault().AbbreviatedMonths[month-1]
+}
+
+// Label returns the translation of a user interface label, or the label
+// itself if it is not translated
+func (l *Locale) Label(label string) string {
+	if translation, ok := l.orDefault().Labels[label]; ok {
+		return translation
+	}
+	return label
+}
+
+// Meridiem returns the localized AM/PM marker of t
+func (l *Locale) Meridiem(t time.Time) string {
+	if t.Hour() >= 12 {
+		return l.orDefault().PM
+	}
+	return l.orDefault().AM
+}
+
+// WithMeridiem adds the localized AM/PM marker of t to the formatted time
+func (l *Locale) WithMeridiem(t time.Time, formatted string) string {
+	meridiem := l.Meridiem(t)
+	if l.orDefault().MeridiemFirst {
+		return meridiem + " " + formatted
+	}
+	return formatted + " " + meridiem
+}
+
+// LongDate returns the full date of t, with weekday and month names
+func (l *Locale) LongDate(t time.Time) string {
+	return l.expand(l.orDefault().LongDatePattern, t)
+}
+
+// MonthYear returns the month and year of t (e.g. "October 2023")
+func (l *Locale) MonthYear(t time.Time) string {
+	return l.expand(l.orDefault().MonthYearPattern, t)
+}
+
+// ShortDate returns the numeric date of t in the order of the locale
+func (l *Locale) ShortDate(t time.Time) string {
+	return t.Format(l.orDefault().ShortDateLayout)
+}
+
+func (l *Locale) expand(pattern string, t time.Time) string {
+	return strings.NewReplacer(
+		"{weekday}", l.Weekday(t.Weekday()),
+		"{day}", strconv.Itoa(t.Day()),
+		"{month}", l.Month(t.Month()),
+		"{year}", strconv.Itoa(t.Year()),
+	).Replace(pattern)
+}
@@ -0,0 +1,94 @@
+package locale
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAllowedLocalesAreBundled(t *testing.T) {
+	for _, name := range AllowedLocales {
+		l, ok := Lookup(name)
+		if !ok {
+			t.Errorf("Lookup(%q): expected bundled locale", name)
+			continue
+		}
+		if l.Name != name {
+			t.Errorf("Lookup(%q): expected name '%s', got '%s'", name, name, l.Name)
+		}
+		for weekday, short := range l.ShortWeekdays {
+			if short == "" || l.Weekdays[weekday] == "" || l.AbbreviatedWeekdays[weekday] == "" {
+				t.Errorf("%s: missing name of weekday %d", name, weekday)
+			}
+		}
+		for month, monthName := range l.Months {
+			if monthName == "" || l.AbbreviatedMonths[month] == "" {
+				t.Errorf("%s: missing name of month %d", name, month+1)
+			}
+		}
+	}
+	if len(locales) != len(AllowedLocales) {
+		t.Errorf("expected %d bundled locales, got %d", len(AllowedLocales), len(locales))
+	}
+}
+
+func TestLookupUnknown(t *testing.T) {
+	if _, ok := Lookup("xx-XX"); ok {
+		t.Errorf("Lookup(%q): expected no locale", "xx-XX")
+	}
+}
+
+func TestNilLocaleIsUSEnglish(t *testing.T) {
+	var l *Locale
+	date := time.Date(2023, 10, 3, 9, 5, 0, 0, time.UTC)
+
+	if got := l.LongDate(date); got != "Tuesday, October 3, 2023" {
+		t.Errorf("expected 'Tuesday, October 3, 2023', got '%s'", got)
+	}
+	if got := l.WithMeridiem(date, "09:05:00"); got != "09:05:00 AM" {
+		t.Errorf("expected '09:05:00 AM', got '%s'", got)
+	}
+	if got := l.Label("Quit"); got != "Quit" {
+		t.Errorf("expected 'Quit', got '%s'", got)
+	}
+}
+
+func TestLabel(t *testing.T) {
+	tests := []struct {
+		locale   string
+		label    string
+		expected string
+	}{
+		{"de-DE", "Quit", "Beenden"},
+		{"fr-FR", "Offset", "Décalage"},
+		{"ja-JP", "Help", "ヘルプ"},
+		{"en-GB", "Quit", "Quit"},
+		{"de-DE", "Untranslated", "Untranslated"},
+	}
+
+	for _, tt := range tests {
+		l, _ := Lookup(tt.locale)
+		if got := l.Label(tt.label); got != tt.expected {
+			t.Errorf("Label(%q, %q): expected '%s', got '%s'", tt.locale, tt.label, tt.expected, got)
+		}
+	}
+}
+
+func TestMonthYear(t *testing.T) {
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		locale   string
+		expected string
+	}{
+		{"en-US", "March 2024"},
+		{"de-DE", "März 2024"},
+		{"es-ES", "marzo de 2024"},
+		{"ja-JP", "2024年3月"},
+	}
+
+	for _, tt := range tests {
+		l, _ := Lookup(tt.locale)
+		if got := l.MonthYear(date); got != tt.expected {
+			t.Errorf("MonthYear(%q): expected '%s', got '%s'", tt.locale, tt.expected, got)
+		}
+	}
+}
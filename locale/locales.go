@@ -0,0 +1,200 @@
+package locale
+
+var englishWeekdays = [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+var englishShortWeekdays = [7]string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"}
+var englishAbbreviatedWeekdays = [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+var englishMonths = [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}
+var englishAbbreviatedMonths = [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
+
+// Names follow the Unicode Common Locale Data Repository (CLDR)
+// See: https://cldr.unicode.org/
+var locales = map[string]*Locale{
+	"en-US": {
+		Name:                "en-US",
+		Weekdays:            englishWeekdays,
+		AbbreviatedWeekdays: englishAbbreviatedWeekdays,
+		ShortWeekdays:       englishShortWeekdays,
+		Months:              englishMonths,
+		AbbreviatedMonths:   englishAbbreviatedMonths,
+		AM:                  "AM",
+		PM:                  "PM",
+		LongDatePattern:     "{weekday}, {month} {day}, {year}",
+		MonthYearPattern:    "{month} {year}",
+		ShortDateLayout:     "01/02/2006",
+	},
+	"en-GB": {
+		Name:                "en-GB",
+		Weekdays:            englishWeekdays,
+		AbbreviatedWeekdays: englishAbbreviatedWeekdays,
+		ShortWeekdays:       englishShortWeekdays,
+		Months:              englishMonths,
+		AbbreviatedMonths:   englishAbbreviatedMonths,
+		AM:                  "am",
+		PM:                  "pm",
+		LongDatePattern:     "{weekday}, {day} {month} {year}",
+		MonthYearPattern:    "{month} {year}",
+		ShortDateLayout:     "02/01/2006",
+	},
+	"de-DE": {
+		Name:                "de-DE",
+		Weekdays:            [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		AbbreviatedWeekdays: [7]string{"So.", "Mo.", "Di.", "Mi.", "Do.", "Fr.", "Sa."},
+		ShortWeekdays:       [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		Months:              [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		AbbreviatedMonths:   [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		AM:                  "AM",
+		PM:                  "PM",
+		LongDatePattern:     "{weekday}, {day}. {month} {year}",
+		MonthYearPattern:    "{month} {year}",
+		ShortDateLayout:     "02.01.2006",
+		Labels: map[string]string{
+			"Quit":           "Beenden",
+			"Help":           "Hilfe",
+			"Offset":         "Abweichung",
+			"Last Sync":      "Letzter Abgleich",
+			"Wk":             "KW",
+			"Leap Seconds":   "Schaltsekunden",
+			"expired {date}": "abgelaufen am {date}",
+			"never":          "nie",
+			"{n}s ago":       "vor {n} s",
+			"{n}m ago":       "vor {n} min",
+			"{n}h ago":       "vor {n} h",
+		},
+	},
+	"fr-FR": {
+		Name:                "fr-FR",
+		Weekdays:            [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		AbbreviatedWeekdays: [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		ShortWeekdays:       [7]string{"di", "lu", "ma", "me", "je", "ve", "sa"},
+		Months:              [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		AbbreviatedMonths:   [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		AM:                  "AM",
+		PM:                  "PM",
+		LongDatePattern:     "{weekday} {day} {month} {year}",
+		MonthYearPattern:    "{month} {year}",
+		ShortDateLayout:     "02/01/2006",
+		Labels: map[string]string{
+			"Quit":           "Quitter",
+			"Help":           "Aide",
+			"Offset":         "Décalage",
+			"Last Sync":      "Dernière synchro",
+			"Wk":             "S",
+			"Leap Seconds":   "Secondes intercalaires",
+			"(offline)":      "(hors ligne)",
+			"expired {date}": "expirées le {date}",
+			"never":          "jamais",
+			"{n}s ago":       "il y a {n} s",
+			"{n}m ago":       "il y a {n} min",
+			"{n}h ago":       "il y a {n} h",
+		},
+	},
+	"es-ES": {
+		Name:                "es-ES",
+		Weekdays:            [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		AbbreviatedWeekdays: [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		ShortWeekdays:       [7]string{"do", "lu", "ma", "mi", "ju", "vi", "sá"},
+		Months:              [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		AbbreviatedMonths:   [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		AM:                  "a. m.",
+		PM:                  "p. m.",
+		LongDatePattern:     "{weekday}, {day} de {month} de {year}",
+		MonthYearPattern:    "{month} de {year}",
+		ShortDateLayout:     "02/01/2006",
+		Labels: map[string]string{
+			"Quit":           "Salir",
+			"Help":           "Ayuda",
+			"Offset":         "Desfase",
+			"Last Sync":      "Última sincronización",
+			"Wk":             "S",
+			"Leap Seconds":   "Segundos intercalares",
+			"(offline)":      "(sin conexión)",
+			"expired {date}": "caducados el {date}",
+			"never":          "nunca",
+			"{n}s ago":       "hace {n} s",
+			"{n}m ago":       "hace {n} min",
+			"{n}h ago":       "hace {n} h",
+		},
+	},
+	"it-IT": {
+		Name:                "it-IT",
+		Weekdays:            [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		AbbreviatedWeekdays: [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+		ShortWeekdays:       [7]string{"do", "lu", "ma", "me", "gi", "ve", "sa"},
+		Months:              [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		AbbreviatedMonths:   [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		AM:                  "AM",
+		PM:                  "PM",
+		LongDatePattern:     "{weekday} {day} {month} {year}",
+		MonthYearPattern:    "{month} {year}",
+		ShortDateLayout:     "02/01/2006",
+		Labels: map[string]string{
+			"Quit":           "Esci",
+			"Help":           "Aiuto",
+			"Offset":         "Scarto",
+			"Last Sync":      "Ultima sincronizzazione",
+			"Wk":             "S",
+			"Leap Seconds":   "Secondi intercalari",
+			"expired {date}": "scaduti il {date}",
+			"never":          "mai",
+			"{n}s ago":       "{n} s fa",
+			"{n}m ago":       "{n} min fa",
+			"{n}h ago":       "{n} h fa",
+		},
+	},
+	"ja-JP": {
+		Name:                "ja-JP",
+		Weekdays:            [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+		AbbreviatedWeekdays: [7]string{"日", "月", "火", "水", "木", "金", "土"},
+		ShortWeekdays:       [7]string{"日", "月", "火", "水", "木", "金", "土"},
+		Months:              [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		AbbreviatedMonths:   [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		AM:                  "午前",
+		PM:                  "午後",
+		MeridiemFirst:       true,
+		LongDatePattern:     "{year}年{month}{day}日 {weekday}",
+		MonthYearPattern:    "{year}年{month}",
+		ShortDateLayout:     "2006/01/02",
+		Labels: map[string]string{
+			"Quit":           "終了",
+			"Help":           "ヘルプ",
+			"Offset":         "オフセット",
+			"Last Sync":      "最終同期",
+			"Wk":             "週",
+			"Leap Seconds":   "うるう秒",
+			"(offline)":      "(オフライン)",
+			"expired {date}": "{date}に期限切れ",
+			"never":          "なし",
+			"{n}s ago":       "{n}秒前",
+			"{n}m ago":       "{n}分前",
+			"{n}h ago":       "{n}時間前",
+		},
+	},
+	"zh-CN": {
+		Name:                "zh-CN",
+		Weekdays:            [7]string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+		AbbreviatedWeekdays: [7]string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+		ShortWeekdays:       [7]string{"日", "一", "二", "三", "四", "五", "六"},
+		Months:              [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		AbbreviatedMonths:   [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		AM:                  "上午",
+		PM:                  "下午",
+		MeridiemFirst:       true,
+		LongDatePattern:     "{year}年{month}{day}日 {weekday}",
+		MonthYearPattern:    "{year}年{month}",
+		ShortDateLayout:     "2006/01/02",
+		Labels: map[string]string{
+			"Quit":           "退出",
+			"Help":           "帮助",
+			"Offset":         "偏差",
+			"Last Sync":      "上次同步",
+			"Wk":             "周",
+			"Leap Seconds":   "闰秒",
+			"(offline)":      "(离线)",
+			"expired {date}": "已于{date}过期",
+			"never":          "从未",
+			"{n}s ago":       "{n}秒前",
+			"{n}m ago":       "{n}分钟前",
+			"{n}h ago":       "{n}小时前",
+		},
+	},
+}
@@ -6,11 +6,17 @@ import (
 	"log"
 	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"chrono-ntp/audio"
+	"chrono-ntp/clock"
 	"chrono-ntp/configuration"
 	"chrono-ntp/display"
+	"chrono-ntp/holidays"
+	"chrono-ntp/leapseconds"
+	"chrono-ntp/locale"
 	"chrono-ntp/ntp"
 )
 
@@ -18,11 +24,47 @@ const (
 	appName                  = "chrono-ntp"
 	appVersion               = "dev"
 	ntpOffsetRefreshInterval = 15 * time.Minute
+	// ntpHistorySampleInterval is the time between the extra queries for the
+	// history graph while it is shown, so it shows jitter within minutes
+	ntpHistorySampleInterval = 30 * time.Second
 )
 
 var allowedTimeFormats = display.AllowedTimeFormats[:]
 var allowedDateFormats = display.AllowedDateFormats[:]
-var offset time.Duration = 0
+var allowedThemes = display.AllowedThemes[:]
+var allowedModes = display.AllowedModes[:]
+var allowedLocales = locale.AllowedLocales[:]
+var clockSync syncState
+
+// syncState is the offset from the NTP server and the server time of the last
+// synchronization, which the refresh goroutine updates while the tickers and
+// the main loop read them
+type syncState struct {
+	mu       sync.Mutex
+	offset   time.Duration
+	lastSync time.Time
+}
+
+func (s *syncState) Offset() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.offset
+}
+
+// Get returns the offset and the time of the last synchronization together, so
+// they belong to the same refresh
+func (s *syncState) Get() (time.Duration, time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.offset, s.lastSync
+}
+
+func (s *syncState) Set(offset time.Duration, lastSync time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.offset = offset
+	s.lastSync = lastSync
+}
 
 func main() {
 	config, err := configuration.LoadConfiguration()
@@ -34,11 +76,31 @@ func main() {
 	timeZone := flag.String("time-zone", config.TimeZone, "Time zone name (e.g., 'America/New_York')")
 	debug := flag.Bool("debug", false, "Show debug information (e.g., offset from NTP server) and exit")
 	hideStatusBar := flag.Bool("hide-status-bar", config.HideStatusBar, "Hide the status bar")
+	healthBorder := flag.Bool("health-border", config.HealthBorder, "Draw a border around the screen colored by the synchronization health")
+	showGraph := flag.Bool("show-graph", config.ShowGraph, "Show a graph of the offset and round-trip time history")
+	showCalendar := flag.Bool("show-calendar", config.ShowCalendar, "Show a calendar of the current month")
+	firstWeekday := flag.String("first-weekday", config.FirstWeekday, "First day of the week in the calendar (e.g., 'monday', 'sunday')")
+	holidaysFile := flag.String("holidays-file", config.HolidaysFile, "iCalendar file with holidays to highlight in the calendar")
+	showAstronomy := flag.Bool("show-astronomy", config.ShowAstronomy, "Show sunrise, sunset, twilight and moon phase for the latitude and longitude")
+	latitude := flag.Float64("latitude", config.Latitude, "Latitude in degrees, north positive (e.g., 52.52)")
+	longitude := flag.Float64("longitude", config.Longitude, "Longitude in degrees, east positive (e.g., 13.405), for the sun and moon and local sidereal time")
 	hideDate := flag.Bool("hide-date", config.HideDate, "Hide the date display")
 	showTimeZone := flag.Bool("show-time-zone", config.ShowTimeZone, "Show the time zone")
-	dateFormat := flag.String("date-format", "YYYY-MM-DD", fmt.Sprintf("Date display format (%s)", strings.Join(allowedDateFormats, ", ")))
-	timeFormat := flag.String("time-format", config.TimeFormat, fmt.Sprintf("Time display format (%s)", strings.Join(allowedTimeFormats, ", ")))
-	beeps := flag.Bool("beeps", config.Beeps, "Play 6 beeps at the end of each minute, with the sixth beep at second 0 (emulates the Greenwich Time Signal)")
+	mode := flag.String("mode", config.Mode, fmt.Sprintf("Display mode (%s)", strings.Join(allowedModes, ", ")))
+	dateFormat := flag.String("date-format", config.DateFormat, fmt.Sprintf("Date display format (%s, or %s followed by a strftime pattern)", strings.Join(allowedDateFormats, ", "), display.CustomFormatPrefix))
+	calendarName := flag.String("calendar", config.Calendar, fmt.Sprintf("Calendar of the date line (%s); the date format applies to gregorian", strings.Join(display.AllowedCalendars[:], ", ")))
+	timeFormat := flag.String("time-format", config.TimeFormat, fmt.Sprintf("Time display format (%s, or %s followed by a strftime pattern)", strings.Join(allowedTimeFormats, ", "), display.CustomFormatPrefix))
+	timePrecision := flag.Int("time-precision", config.TimePrecision, fmt.Sprintf("Number of fractional second digits (0-%d) for ISO8601, 12h, 12h_AM_PM, unix, cocoa, decimal, tai, gps, loran, tt, gmst, gast and lst", display.MaxTimePrecision))
+	dayCountPrecision := flag.Int("day-count-precision", config.DayCountPrecision, fmt.Sprintf("Number of decimals (0-%d) for jd, mjd, rata-die, excel-serial and mars-sol-date", display.MaxDayCountPrecision))
+	marsLocation := flag.String("mars-location", config.MarsLocation, fmt.Sprintf("Lander (%s) or longitude in degrees east on Mars for local mean solar time in the mars time format, instead of Coordinated Mars Time", strings.Join(display.AllowedMarsLanders, ", ")))
+	leapSecondsFile := flag.String("leap-seconds-file", config.LeapSecondsFile, "Leap second list (leap-seconds.list) for the tai, gps, loran and tt time formats, instead of the built-in list")
+	tickIndicator := flag.Bool("tick-indicator", config.TickIndicator, "Flash the time at each second boundary (e.g. for setting watches)")
+	beeps := flag.Bool("beeps", config.Beeps, "Play the time signal of -time-signal, with its minute marker at second 0")
+	timeSignal := flag.String("time-signal", config.TimeSignal, fmt.Sprintf("Time signal emulated by -beeps (%s)", strings.Join(audio.AllowedTimeSignals[:], ", ")))
+	chimes := flag.String("chimes", config.Chimes, fmt.Sprintf("Chimes on the hour and quarter hours (%s)", strings.Join(audio.AllowedChimes[:], ", ")))
+	chimeQuietHours := flag.String("chime-quiet-hours", config.ChimeQuietHours, "Daily period without chimes (e.g., '22:00-07:00')")
+	localeName := flag.String("locale", config.Locale, fmt.Sprintf("Language of weekday and month names and labels (%s)", strings.Join(allowedLocales, ", ")))
+	theme := flag.String("theme", config.Theme, fmt.Sprintf("Color theme (%s)", strings.Join(allowedThemes, ", ")))
 	version := flag.Bool("version", false, "Show version and exit")
 	offline := flag.Bool("offline", false, "Run in offline mode (use system time, ignore NTP server)")
 	writeConfig := flag.Bool("write-config", false, "Write configuration file (merged from existing configuration file and flags)")
@@ -55,24 +117,112 @@ func main() {
 		return
 	}
 
-	if !slices.Contains(allowedDateFormats, *dateFormat) {
-		log.Fatalf("Error: invalid date format '%s'. Allowed values: %s", *dateFormat, strings.Join(allowedDateFormats, ", "))
+	if !slices.Contains(allowedModes, *mode) {
+		log.Fatalf("Error: invalid mode '%s'. Allowed values: %s", *mode, strings.Join(allowedModes, ", "))
+	}
+
+	if pattern, ok := display.CustomFormatPattern(*dateFormat); ok {
+		if err := display.ValidateStrftime(pattern); err != nil {
+			log.Fatalf("Error: invalid date format '%s': %v", *dateFormat, err)
+		}
+	} else if !slices.Contains(allowedDateFormats, *dateFormat) {
+		log.Fatalf("Error: invalid date format '%s'. Allowed values: %s, %s<pattern>", *dateFormat, strings.Join(allowedDateFormats, ", "), display.CustomFormatPrefix)
+	}
+
+	dateCalendar, ok := display.LookupCalendar(*calendarName)
+	if !ok {
+		log.Fatalf("Error: invalid calendar '%s'. Allowed values: %s", *calendarName, strings.Join(display.AllowedCalendars[:], ", "))
+	}
+
+	if pattern, ok := display.CustomFormatPattern(*timeFormat); ok {
+		if err := display.ValidateStrftime(pattern); err != nil {
+			log.Fatalf("Error: invalid time format '%s': %v", *timeFormat, err)
+		}
+	} else if !slices.Contains(allowedTimeFormats, *timeFormat) {
+		log.Fatalf("Error: invalid time format '%s'. Allowed values: %s, %s<pattern>", *timeFormat, strings.Join(allowedTimeFormats, ", "), display.CustomFormatPrefix)
+	}
+
+	if *timePrecision < 0 || *timePrecision > display.MaxTimePrecision {
+		log.Fatalf("Error: invalid time precision %d. Allowed values: 0-%d", *timePrecision, display.MaxTimePrecision)
 	}
 
-	if !slices.Contains(allowedTimeFormats, *timeFormat) {
-		log.Fatalf("Error: invalid time format '%s'. Allowed values: %s", *timeFormat, strings.Join(allowedTimeFormats, ", "))
+	if *dayCountPrecision < 0 || *dayCountPrecision > display.MaxDayCountPrecision {
+		log.Fatalf("Error: invalid day count precision %d. Allowed values: 0-%d", *dayCountPrecision, display.MaxDayCountPrecision)
+	}
+
+	calendarFirstWeekday, err := display.ParseWeekday(*firstWeekday)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
+	if *latitude < -90 || *latitude > 90 {
+		log.Fatalf("Error: invalid latitude %g. Allowed values: -90 to 90", *latitude)
+	}
+
+	if *longitude < -180 || *longitude > 180 {
+		log.Fatalf("Error: invalid longitude %g. Allowed values: -180 to 180", *longitude)
+	}
+
+	displayMarsLocation, err := display.ParseMarsLocation(*marsLocation)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
+	if !slices.Contains(audio.AllowedTimeSignals[:], *timeSignal) {
+		log.Fatalf("Error: invalid time signal '%s'. Allowed values: %s", *timeSignal, strings.Join(audio.AllowedTimeSignals[:], ", "))
+	}
+
+	if !slices.Contains(audio.AllowedChimes[:], *chimes) {
+		log.Fatalf("Error: invalid chimes '%s'. Allowed values: %s", *chimes, strings.Join(audio.AllowedChimes[:], ", "))
+	}
+
+	quietHours, err := audio.ParseQuietHours(*chimeQuietHours)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
+	if !slices.Contains(allowedThemes, *theme) {
+		log.Fatalf("Error: invalid theme '%s'. Allowed values: %s", *theme, strings.Join(allowedThemes, ", "))
+	}
+
+	displayLocale, ok := locale.Lookup(*localeName)
+	if !ok {
+		log.Fatalf("Error: invalid locale '%s'. Allowed values: %s", *localeName, strings.Join(allowedLocales, ", "))
 	}
 
 	if *writeConfig {
 		mergedConfig := configuration.Configuration{
-			Server:        *ntpServer,
-			TimeZone:      *timeZone,
-			HideStatusBar: *hideStatusBar,
-			HideDate:      *hideDate,
-			ShowTimeZone:  *showTimeZone,
-			TimeFormat:    *timeFormat,
-			Beeps:         *beeps,
-			Offline:       *offline,
+			Server:            *ntpServer,
+			TimeZone:          *timeZone,
+			HideStatusBar:     *hideStatusBar,
+			HideDate:          *hideDate,
+			HealthBorder:      *healthBorder,
+			ShowGraph:         *showGraph,
+			ShowCalendar:      *showCalendar,
+			FirstWeekday:      *firstWeekday,
+			HolidaysFile:      *holidaysFile,
+			ShowAstronomy:     *showAstronomy,
+			Latitude:          *latitude,
+			Longitude:         *longitude,
+			ShowTimeZone:      *showTimeZone,
+			Mode:              *mode,
+			DateFormat:        *dateFormat,
+			Calendar:          *calendarName,
+			TimeFormat:        *timeFormat,
+			TimePrecision:     *timePrecision,
+			DayCountPrecision: *dayCountPrecision,
+			MarsLocation:      *marsLocation,
+			LeapSecondsFile:   *leapSecondsFile,
+			TickIndicator:     *tickIndicator,
+			Beeps:             *beeps,
+			TimeSignal:        *timeSignal,
+			Chimes:            *chimes,
+			ChimeQuietHours:   *chimeQuietHours,
+			Offline:           *offline,
+			Theme:             *theme,
+			Locale:            *localeName,
+			Colors:            config.Colors,
+			KeyBindings:       config.KeyBindings,
 		}
 		configPath, err := configuration.WriteConfiguration(mergedConfig)
 		if err == nil {
@@ -83,6 +233,36 @@ func main() {
 		return
 	}
 
+	keyBindings, err := display.NewKeyBindings(config.KeyBindings)
+	if err != nil {
+		log.Fatalf("Error: invalid key bindings: %v", err)
+	}
+
+	colors := map[string]display.ElementColors{}
+	for element, c := range config.Colors {
+		colors[element] = display.ElementColors{Foreground: c.Foreground, Background: c.Background}
+	}
+	displayTheme, err := display.NewTheme(*theme, colors)
+	if err != nil {
+		log.Fatalf("Error: invalid colors: %v", err)
+	}
+
+	var leapSecondTable *leapseconds.Table
+	if *leapSecondsFile != "" {
+		leapSecondTable, err = leapseconds.Load(*leapSecondsFile)
+		if err != nil {
+			log.Fatalf("Failed to load leap seconds (%s): %v", *leapSecondsFile, err)
+		}
+	}
+
+	var calendarHolidays *holidays.Holidays
+	if *holidaysFile != "" {
+		calendarHolidays, err = holidays.Load(*holidaysFile)
+		if err != nil {
+			log.Fatalf("Failed to load holidays (%s): %v", *holidaysFile, err)
+		}
+	}
+
 	timeZoneLocation, err := time.LoadLocation(*timeZone)
 	if err != nil {
 		log.Fatalf("Failed to load location: %v", err)
@@ -92,10 +272,9 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to initialize audio context: %v", err)
 	}
-	beepsEnabled := *beeps && !slices.Contains([]string{".beat", "septimal", "lunar", "mars"}, *timeFormat)
 
 	// Initialize display early to show loading message
-	d, err := display.NewDisplay()
+	d, err := display.NewDisplay(keyBindings, displayTheme)
 	if err != nil {
 		log.Fatalf("Failed to create display: %v", err)
 	}
@@ -104,56 +283,148 @@ func main() {
 	}
 	defer d.Finalize()
 
+	refreshNtpChan := make(chan struct{}, 1)
+	var graphShown atomic.Bool
+	graphShown.Store(*showGraph)
+	var ntpClient *ntp.Ntp
 	if !*offline {
 		d.SetInitText("Querying NTP server for time...")
 
-		ntpClient, err := ntp.NewNtp(*ntpServer)
+		ntpClient, err = ntp.NewNtp(*ntpServer)
 		if err != nil {
 			log.Fatalf("Failed to get time from NTP server %s: %v", *ntpServer, err)
 		}
-		offset = ntpClient.Offset()
+		clockSync.Set(ntpClient.Offset(), ntpClient.ServerTime())
 
 		go func() {
 			ticker := time.NewTicker(ntpOffsetRefreshInterval)
 			defer ticker.Stop()
-			for range ticker.C {
+			for {
+				select {
+				case <-ticker.C:
+				case <-refreshNtpChan:
+				}
 				if err := ntpClient.Refresh(); err == nil {
-					offset = ntpClient.Offset()
+					clockSync.Set(ntpClient.Offset(), ntpClient.ServerTime())
 				}
 				// If error, ignore and keep previous offset
 			}
 		}()
+
+		go func() {
+			ticker := time.NewTicker(ntpHistorySampleInterval)
+			defer ticker.Stop()
+			for range ticker.C {
+				if graphShown.Load() {
+					ntpClient.Sample() // Missing samples are gaps in the history
+				}
+			}
+		}()
 	}
 
-	quitChan := make(chan struct{})
-	go d.PollEvents(quitChan)
+	actionChan := make(chan display.Action)
+	go d.PollEvents(actionChan)
+
+	// Redraws and beeps are aligned to the boundaries of the NTP-corrected time,
+	// so the display changes and the beeps start on the true second
+	offsetFunc := clockSync.Offset
+	// The redraw interval depends on the time format, so the ticker is replaced
+	// when the format changes
+	newDisplayTicker := func(timeFormat string) *clock.AlignedTicker {
+		return clock.NewAlignedTickerIn(clock.System, display.RedrawIntervalFor(timeFormat, *timePrecision, *dayCountPrecision, *tickIndicator), timeZoneLocation, offsetFunc)
+	}
+	displayTicker := newDisplayTicker(*timeFormat)
+	defer func() { displayTicker.Stop() }()
+	beepTicker := clock.NewAlignedTicker(clock.System, time.Second, offsetFunc)
+	defer beepTicker.Stop()
 
-	displayTicker := time.NewTicker(100 * time.Millisecond)
-	defer displayTicker.Stop()
+	displayState := display.DisplayState{
+		Mode:              *mode,
+		DateFormat:        *dateFormat,
+		Calendar:          dateCalendar,
+		TimeFormat:        *timeFormat,
+		TimePrecision:     *timePrecision,
+		TickIndicator:     *tickIndicator,
+		HideDate:          *hideDate,
+		ShowTimeZone:      *showTimeZone,
+		HideStatusBar:     *hideStatusBar,
+		HealthBorder:      *healthBorder,
+		ShowGraph:         *showGraph,
+		ShowCalendar:      *showCalendar,
+		FirstWeekday:      calendarFirstWeekday,
+		Holidays:          calendarHolidays,
+		LeapSeconds:       leapSecondTable,
+		Locale:            displayLocale,
+		ShowAstronomy:     *showAstronomy,
+		Latitude:          *latitude,
+		Longitude:         *longitude,
+		DayCountPrecision: *dayCountPrecision,
+		MarsLocation:      displayMarsLocation,
+		TimeZone:          timeZoneLocation,
+		Offline:           *offline,
+	}
+	beepsEnabled := *beeps
+	clockChimes := audio.Chimes{Style: *chimes, QuietHours: quietHours}
 
 	for {
+		var now time.Time
 		select {
-		case <-displayTicker.C:
-			now := time.Now().Add(-offset).In(timeZoneLocation)
-
-			displayState := &display.DisplayState{
-				Now:           now,
-				DateFormat:    *dateFormat,
-				TimeFormat:    *timeFormat,
-				HideDate:      *hideDate,
-				ShowTimeZone:  *showTimeZone,
-				HideStatusBar: *hideStatusBar,
-				TimeZone:      timeZoneLocation,
-				Offset:        offset,
-				Offline:       *offline,
+		case now = <-beepTicker.C:
+			if beepsEnabled && display.FollowsWallClock(displayState.TimeFormat) {
+				audio.BeepTick(audioContext, now.In(timeZoneLocation), *timeSignal)
+			}
+			// Chimes follow the wall clock of the time zone, whatever the time format
+			audio.ChimeTick(audioContext, now.In(timeZoneLocation), clockChimes)
+			continue
+		case now = <-displayTicker.C:
+		case action := <-actionChan:
+			now = time.Now().Add(-clockSync.Offset())
+			switch action {
+			case display.ActionQuit:
+				return
+			case display.ActionNextMode:
+				displayState.Mode = display.NextMode(displayState.Mode)
+			case display.ActionNextTimeFormat:
+				displayState.TimeFormat = display.NextTimeFormat(displayState.TimeFormat, *timeFormat)
+				displayTicker.Stop()
+				displayTicker = newDisplayTicker(displayState.TimeFormat)
+			case display.ActionNextDateFormat:
+				displayState.DateFormat = display.NextDateFormat(displayState.DateFormat, *dateFormat)
+			case display.ActionToggleDate:
+				displayState.HideDate = !displayState.HideDate
+			case display.ActionToggleTimeZone:
+				displayState.ShowTimeZone = !displayState.ShowTimeZone
+			case display.ActionToggleStatusBar:
+				displayState.HideStatusBar = !displayState.HideStatusBar
+			case display.ActionToggleGraph:
+				displayState.ShowGraph = !displayState.ShowGraph
+				graphShown.Store(displayState.ShowGraph)
+			case display.ActionToggleCalendar:
+				displayState.ShowCalendar = !displayState.ShowCalendar
+			case display.ActionToggleAstronomy:
+				displayState.ShowAstronomy = !displayState.ShowAstronomy
+			case display.ActionToggleBeeps:
+				beepsEnabled = !beepsEnabled
+			case display.ActionRefreshNtp:
+				select {
+				case refreshNtpChan <- struct{}{}:
+				default: // Refresh already pending
+				}
+			case display.ActionToggleHelp:
+				displayState.ShowHelp = !displayState.ShowHelp
 			}
-			d.Update(*displayState)
+		}
 
-			if beepsEnabled {
-				audio.BeepTick(audioContext, now)
+		displayState.Now = now.In(timeZoneLocation)
+		displayState.Offset, displayState.LastSync = clockSync.Get()
+		if ntpClient != nil && displayState.ShowGraph {
+			displayState.HistoryTimes, displayState.OffsetHistory, displayState.RTTHistory = nil, nil, nil
+			for _, sample := range ntpClient.History() {
+				displayState.HistoryTimes = append(displayState.HistoryTimes, sample.Time)
+				displayState.OffsetHistory = append(displayState.OffsetHistory, sample.Offset)
+				displayState.RTTHistory = append(displayState.RTTHistory, sample.RTT)
 			}
-		case <-quitChan:
-			return
 		}
+		d.Update(displayState)
 	}
 }
@@ -6,14 +6,18 @@ import (
 	"github.com/beevik/ntp"
 )
 
+// historySize is the number of samples kept for the offset history
+const historySize = 120
+
 type Ntp struct {
 	server      string
 	offset      time.Duration
 	lastNtpTime time.Time
+	history     *SampleBuffer
 }
 
 func NewNtp(server string) (*Ntp, error) {
-	n := &Ntp{server: server}
+	n := &Ntp{server: server, history: NewSampleBuffer(historySize)}
 	err := n.Refresh()
 	if err != nil {
 		return nil, err
@@ -29,12 +33,43 @@ func (n *Ntp) ServerTime() time.Time {
 	return n.lastNtpTime
 }
 
+// History returns the samples of the most recent successful refreshes and
+// samples, oldest first
+func (n *Ntp) History() []Sample {
+	return n.history.Samples()
+}
+
 func (n *Ntp) Refresh() error {
-	ntpTime, err := ntp.Time(n.server)
+	sample, err := n.query()
+	if err != nil {
+		return err
+	}
+	n.lastNtpTime = sample.Time
+	n.offset = sample.Offset
+	n.history.Add(sample)
+	return nil
+}
+
+// Sample queries the server and adds the result to the history, without
+// changing the offset. It is safe to call while Refresh runs, so the history
+// can be sampled more often than the offset is refreshed.
+func (n *Ntp) Sample() error {
+	sample, err := n.query()
 	if err != nil {
 		return err
 	}
-	n.lastNtpTime = ntpTime
-	n.offset = time.Since(ntpTime)
+	n.history.Add(sample)
 	return nil
 }
+
+func (n *Ntp) query() (Sample, error) {
+	response, err := ntp.Query(n.server)
+	if err != nil {
+		return Sample{}, err
+	}
+	if err := response.Validate(); err != nil {
+		return Sample{}, err
+	}
+	ntpTime := time.Now().Add(response.ClockOffset)
+	return Sample{Time: ntpTime, Offset: time.Since(ntpTime), RTT: response.RTT}, nil
+}
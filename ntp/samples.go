@@ -0,0 +1,49 @@
+package ntp
+
+import (
+	"sync"
+	"time"
+)
+
+// Sample is the result of a single successful NTP query
+type Sample struct {
+	Time   time.Time // Server time of the query
+	Offset time.Duration
+	RTT    time.Duration
+}
+
+// SampleBuffer keeps the most recent samples up to its capacity, dropping the
+// oldest sample when it is full. It is safe for concurrent use.
+type SampleBuffer struct {
+	mu      sync.Mutex
+	samples []Sample
+	next    int
+	full    bool
+}
+
+func NewSampleBuffer(capacity int) *SampleBuffer {
+	return &SampleBuffer{samples: make([]Sample, capacity)}
+}
+
+func (b *SampleBuffer) Add(sample Sample) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.samples) == 0 {
+		return
+	}
+	b.samples[b.next] = sample
+	b.next = (b.next + 1) % len(b.samples)
+	if b.next == 0 {
+		b.full = true
+	}
+}
+
+// Samples returns a copy of the samples, oldest first
+func (b *SampleBuffer) Samples() []Sample {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.full {
+		return append([]Sample{}, b.samples[:b.next]...)
+	}
+	return append(append([]Sample{}, b.samples[b.next:]...), b.samples[:b.next]...)
+}
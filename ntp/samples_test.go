@@ -0,0 +1,66 @@
+package ntp
+
+import (
+	"testing"
+	"time"
+)
+
+func offsets(samples []Sample) []time.Duration {
+	result := []time.Duration{}
+	for _, sample := range samples {
+		result = append(result, sample.Offset)
+	}
+	return result
+}
+
+func TestSampleBuffer(t *testing.T) {
+	buffer := NewSampleBuffer(3)
+	if got := buffer.Samples(); len(got) != 0 {
+		t.Fatalf("expected no samples, got %v", got)
+	}
+
+	tests := []struct {
+		add      time.Duration
+		expected []time.Duration
+	}{
+		{1, []time.Duration{1}},
+		{2, []time.Duration{1, 2}},
+		{3, []time.Duration{1, 2, 3}},
+		{4, []time.Duration{2, 3, 4}},
+		{5, []time.Duration{3, 4, 5}},
+		{6, []time.Duration{4, 5, 6}},
+		{7, []time.Duration{5, 6, 7}},
+	}
+
+	for _, tt := range tests {
+		buffer.Add(Sample{Offset: tt.add})
+		got := offsets(buffer.Samples())
+		if len(got) != len(tt.expected) {
+			t.Fatalf("after adding %d: expected %v, got %v", tt.add, tt.expected, got)
+		}
+		for i := range got {
+			if got[i] != tt.expected[i] {
+				t.Fatalf("after adding %d: expected %v, got %v", tt.add, tt.expected, got)
+			}
+		}
+	}
+}
+
+func TestSampleBuffer_SamplesIsCopy(t *testing.T) {
+	buffer := NewSampleBuffer(2)
+	buffer.Add(Sample{Offset: 1})
+	samples := buffer.Samples()
+	samples[0].Offset = 42
+
+	if got := buffer.Samples()[0].Offset; got != 1 {
+		t.Errorf("expected buffer to be unchanged, got %v", got)
+	}
+}
+
+func TestSampleBuffer_ZeroCapacity(t *testing.T) {
+	buffer := NewSampleBuffer(0)
+	buffer.Add(Sample{Offset: 1})
+	if got := buffer.Samples(); len(got) != 0 {
+		t.Errorf("expected no samples, got %v", got)
+	}
+}